@@ -0,0 +1,717 @@
+package sourceview
+
+// #cgo pkg-config: gtksourceview-3.0
+// #include <gtksourceview/gtksourcecompletion.h>
+// #include <gtksourceview/gtksourcecompletioncontext.h>
+// #include <gtksourceview/gtksourcecompletioninfo.h>
+// #include <gtksourceview/gtksourcecompletionitem.h>
+// #include <gtksourceview/gtksourcecompletionproposal.h>
+// #include <gtksourceview/gtksourcecompletionprovider.h>
+// #include <gtksourceview/gtksourceview.h>
+// #include <gtksourceview/completion-providers/words/gtksourcecompletionwords.h>
+// #include <gtksourceview/gtksourceview-typebuiltins.h>
+// #include "sourceview.go.h"
+// #include "completion.go.h"
+import "C"
+import (
+	"errors"
+	"sync"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+func init() {
+	tm := []glib.TypeMarshaler{
+		// Enums
+		{glib.Type(C.gtk_source_completion_activation_get_type()), marshalSourceCompletionActivation},
+
+		// Objects/Interfaces
+		{glib.Type(C.gtk_source_completion_get_type()), marshalSourceCompletion},
+		{glib.Type(C.gtk_source_completion_context_get_type()), marshalSourceCompletionContext},
+		{glib.Type(C.gtk_source_completion_info_get_type()), marshalSourceCompletionInfo},
+		{glib.Type(C.gtk_source_completion_item_get_type()), marshalSourceCompletionItem},
+		{glib.Type(C.gtk_source_completion_proposal_get_type()), marshalSourceCompletionProposal},
+		{glib.Type(C.gtk_source_completion_provider_get_type()), marshalSourceCompletionProvider},
+		{glib.Type(C.gtk_source_completion_words_get_type()), marshalSourceCompletionWords},
+	}
+	glib.RegisterGValueMarshalers(tm)
+
+	gtk.WrapMap["GtkSourceCompletion"] = wrapSourceCompletion
+	gtk.WrapMap["GtkSourceCompletionContext"] = wrapSourceCompletionContext
+	gtk.WrapMap["GtkSourceCompletionInfo"] = wrapSourceCompletionInfo
+	gtk.WrapMap["GtkSourceCompletionItem"] = wrapSourceCompletionItem
+	gtk.WrapMap["GtkSourceCompletionProposal"] = wrapSourceCompletionProposal
+	gtk.WrapMap["GtkSourceCompletionProvider"] = wrapSourceCompletionProvider
+	gtk.WrapMap["GtkSourceCompletionWords"] = wrapSourceCompletionWords
+}
+
+/*
+ * Constants
+ */
+
+// SourceCompletionActivation is a representation of GtkSourceCompletionActivation.
+type SourceCompletionActivation int
+
+const (
+	SOURCE_COMPLETION_ACTIVATION_NONE           SourceCompletionActivation = C.GTK_SOURCE_COMPLETION_ACTIVATION_NONE
+	SOURCE_COMPLETION_ACTIVATION_INTERACTIVE    SourceCompletionActivation = C.GTK_SOURCE_COMPLETION_ACTIVATION_INTERACTIVE
+	SOURCE_COMPLETION_ACTIVATION_USER_REQUESTED SourceCompletionActivation = C.GTK_SOURCE_COMPLETION_ACTIVATION_USER_REQUESTED
+)
+
+func marshalSourceCompletionActivation(p uintptr) (interface{}, error) {
+	c := C.g_value_get_flags((*C.GValue)(unsafe.Pointer(p)))
+	return SourceCompletionActivation(c), nil
+}
+
+/*
+ * GtkSourceCompletion
+ */
+
+// SourceCompletion is a representation of GtkSourceCompletion.
+type SourceCompletion struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GtkSourceCompletion.
+func (v *SourceCompletion) native() *C.GtkSourceCompletion {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceCompletion(p)
+}
+
+func marshalSourceCompletion(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceCompletion(obj), nil
+}
+
+func wrapSourceCompletion(obj *glib.Object) *SourceCompletion {
+	return &SourceCompletion{obj}
+}
+
+// GetCompletion is a wrapper around gtk_source_view_get_completion().
+func (v *SourceView) GetCompletion() (*SourceCompletion, error) {
+	c := C.gtk_source_view_get_completion(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceCompletion(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// AddProvider is a wrapper around gtk_source_completion_add_provider().
+func (v *SourceCompletion) AddProvider(provider ISourceCompletionProvider) error {
+	var err *C.GError
+	c := C.gtk_source_completion_add_provider(v.native(), provider.toSourceCompletionProvider(), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// RemoveProvider is a wrapper around gtk_source_completion_remove_provider().
+func (v *SourceCompletion) RemoveProvider(provider ISourceCompletionProvider) error {
+	var err *C.GError
+	c := C.gtk_source_completion_remove_provider(v.native(), provider.toSourceCompletionProvider(), &err)
+	if !gobool(c) {
+		return goError(err)
+	}
+	return nil
+}
+
+// GetProviders is a wrapper around gtk_source_completion_get_providers().
+func (v *SourceCompletion) GetProviders() []*SourceCompletionProvider {
+	var providers []*SourceCompletionProvider
+	for l := C.gtk_source_completion_get_providers(v.native()); l != nil; l = l.next {
+		providers = append(providers, wrapSourceCompletionProvider(glib.Take(unsafe.Pointer(l.data))))
+	}
+	return providers
+}
+
+// GetView is a wrapper around gtk_source_completion_get_view().
+func (v *SourceCompletion) GetView() (*SourceView, error) {
+	c := C.gtk_source_completion_get_view(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceView(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetInfoWindow is a wrapper around gtk_source_completion_get_info_window().
+func (v *SourceCompletion) GetInfoWindow() (*SourceCompletionInfo, error) {
+	c := C.gtk_source_completion_get_info_window(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceCompletionInfo(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// CreateContext is a wrapper around gtk_source_completion_create_context().
+// A nil position creates the context at the cursor.
+func (v *SourceCompletion) CreateContext(position *gtk.TextIter) (*SourceCompletionContext, error) {
+	c := C.gtk_source_completion_create_context(v.native(), nativeTextIter(position))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceCompletionContext(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// Hide is a wrapper around gtk_source_completion_hide().
+func (v *SourceCompletion) Hide() {
+	C.gtk_source_completion_hide(v.native())
+}
+
+// BlockInteractive is a wrapper around gtk_source_completion_block_interactive().
+func (v *SourceCompletion) BlockInteractive() {
+	C.gtk_source_completion_block_interactive(v.native())
+}
+
+// UnblockInteractive is a wrapper around gtk_source_completion_unblock_interactive().
+func (v *SourceCompletion) UnblockInteractive() {
+	C.gtk_source_completion_unblock_interactive(v.native())
+}
+
+/*
+ * GtkSourceCompletionContext
+ */
+
+// SourceCompletionContext is a representation of GtkSourceCompletionContext.
+type SourceCompletionContext struct {
+	glib.InitiallyUnowned
+}
+
+// native returns a pointer to the underlying GtkSourceCompletionContext.
+func (v *SourceCompletionContext) native() *C.GtkSourceCompletionContext {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceCompletionContext(p)
+}
+
+func marshalSourceCompletionContext(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceCompletionContext(obj), nil
+}
+
+func wrapSourceCompletionContext(obj *glib.Object) *SourceCompletionContext {
+	return &SourceCompletionContext{glib.InitiallyUnowned{obj}}
+}
+
+// AddProposals is a wrapper around gtk_source_completion_context_add_proposals().
+// finished must be true once provider has added all its proposals.
+func (v *SourceCompletionContext) AddProposals(provider ISourceCompletionProvider, proposals []ISourceCompletionProposal, finished bool) {
+	var list *C.GList
+	for _, proposal := range proposals {
+		list = C.g_list_prepend(list, C.gpointer(unsafe.Pointer(proposal.toSourceCompletionProposal())))
+	}
+	list = C.g_list_reverse(list)
+	defer C.g_list_free(list)
+
+	C.gtk_source_completion_context_add_proposals(v.native(), provider.toSourceCompletionProvider(),
+		list, gbool(finished))
+}
+
+// GetIter is a wrapper around gtk_source_completion_context_get_iter().
+// The returned bool is false if the completion position was deleted from
+// the buffer.
+func (v *SourceCompletionContext) GetIter() (*gtk.TextIter, bool) {
+	var iter C.GtkTextIter
+	c := C.gtk_source_completion_context_get_iter(v.native(), &iter)
+	return (*gtk.TextIter)(unsafe.Pointer(&iter)), gobool(c)
+}
+
+// GetActivation is a wrapper around gtk_source_completion_context_get_activation().
+func (v *SourceCompletionContext) GetActivation() SourceCompletionActivation {
+	return SourceCompletionActivation(C.gtk_source_completion_context_get_activation(v.native()))
+}
+
+/*
+ * GtkSourceCompletionInfo
+ */
+
+// SourceCompletionInfo is a representation of GtkSourceCompletionInfo.
+type SourceCompletionInfo struct {
+	gtk.Window
+}
+
+// native returns a pointer to the underlying GtkSourceCompletionInfo.
+func (v *SourceCompletionInfo) native() *C.GtkSourceCompletionInfo {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceCompletionInfo(p)
+}
+
+func marshalSourceCompletionInfo(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceCompletionInfo(obj), nil
+}
+
+func wrapSourceCompletionInfo(obj *glib.Object) *SourceCompletionInfo {
+	return &SourceCompletionInfo{
+		gtk.Window{
+			gtk.Bin{
+				gtk.Container{
+					gtk.Widget{
+						glib.InitiallyUnowned{obj},
+					},
+				},
+			},
+		},
+	}
+}
+
+// SourceCompletionInfoNew is a wrapper around gtk_source_completion_info_new().
+func SourceCompletionInfoNew() (*SourceCompletionInfo, error) {
+	c := C.gtk_source_completion_info_new()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceCompletionInfo(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// MoveToIter is a wrapper around gtk_source_completion_info_move_to_iter().
+// A nil iter moves the window to the cursor of view.
+func (v *SourceCompletionInfo) MoveToIter(view *gtk.TextView, iter *gtk.TextIter) {
+	C.gtk_source_completion_info_move_to_iter(v.native(),
+		C.toGtkTextView(unsafe.Pointer(view.GObject)), nativeTextIter(iter))
+}
+
+/*
+ * GtkSourceCompletionItem
+ */
+
+// SourceCompletionItem is a representation of GtkSourceCompletionItem.
+type SourceCompletionItem struct {
+	*glib.Object
+
+	SourceCompletionProposal
+}
+
+// native returns a pointer to the underlying GtkSourceCompletionItem.
+func (v *SourceCompletionItem) native() *C.GtkSourceCompletionItem {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceCompletionItem(p)
+}
+
+func marshalSourceCompletionItem(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceCompletionItem(obj), nil
+}
+
+func wrapSourceCompletionItem(obj *glib.Object) *SourceCompletionItem {
+	proposal := wrapSourceCompletionProposal(obj)
+	return &SourceCompletionItem{obj, *proposal}
+}
+
+// SourceCompletionItemNew is a wrapper around gtk_source_completion_item_new2().
+func SourceCompletionItemNew() (*SourceCompletionItem, error) {
+	c := C.gtk_source_completion_item_new2()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceCompletionItem(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// SetLabel is a wrapper around gtk_source_completion_item_set_label().
+// An empty string unsets it.
+func (v *SourceCompletionItem) SetLabel(label string) {
+	cstr := cstringOrNil(label)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_completion_item_set_label(v.native(), cstr)
+}
+
+// SetMarkup is a wrapper around gtk_source_completion_item_set_markup().
+// An empty string unsets it.
+func (v *SourceCompletionItem) SetMarkup(markup string) {
+	cstr := cstringOrNil(markup)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_completion_item_set_markup(v.native(), cstr)
+}
+
+// SetText is a wrapper around gtk_source_completion_item_set_text().
+// An empty string unsets it.
+func (v *SourceCompletionItem) SetText(text string) {
+	cstr := cstringOrNil(text)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_completion_item_set_text(v.native(), cstr)
+}
+
+// SetIcon is a wrapper around gtk_source_completion_item_set_icon().
+func (v *SourceCompletionItem) SetIcon(icon *gdk.Pixbuf) {
+	C.gtk_source_completion_item_set_icon(v.native(), nativePixbuf(icon))
+}
+
+// SetIconName is a wrapper around gtk_source_completion_item_set_icon_name().
+// An empty name unsets the icon.
+func (v *SourceCompletionItem) SetIconName(iconName string) {
+	cstr := cstringOrNil(iconName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_completion_item_set_icon_name(v.native(), cstr)
+}
+
+// SetGIcon is a wrapper around gtk_source_completion_item_set_gicon().
+// gicon must implement the GIcon interface, or be nil.
+func (v *SourceCompletionItem) SetGIcon(gicon *glib.Object) {
+	C.gtk_source_completion_item_set_gicon(v.native(), nativeGIcon(gicon))
+}
+
+// SetInfo is a wrapper around gtk_source_completion_item_set_info().
+// An empty string unsets it.
+func (v *SourceCompletionItem) SetInfo(info string) {
+	cstr := cstringOrNil(info)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_completion_item_set_info(v.native(), cstr)
+}
+
+/*
+ * GtkSourceCompletionProposal
+ */
+
+// ISourceCompletionProposal is an interface type implemented by all structs
+// embedding a GtkSourceCompletionProposal. It is meant to be used as an
+// argument type for wrapper functions that wrap around a C function taking a
+// GtkSourceCompletionProposal.
+type ISourceCompletionProposal interface {
+	toSourceCompletionProposal() *C.GtkSourceCompletionProposal
+}
+
+// SourceCompletionProposal is a representation of GtkSourceView's
+// GtkSourceCompletionProposal GInterface.
+type SourceCompletionProposal struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GObject as a GtkSourceCompletionProposal.
+func (v *SourceCompletionProposal) native() *C.GtkSourceCompletionProposal {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceCompletionProposal(p)
+}
+
+func (v *SourceCompletionProposal) toSourceCompletionProposal() *C.GtkSourceCompletionProposal {
+	if v == nil {
+		return nil
+	}
+	return v.native()
+}
+
+func marshalSourceCompletionProposal(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceCompletionProposal(obj), nil
+}
+
+func wrapSourceCompletionProposal(obj *glib.Object) *SourceCompletionProposal {
+	return &SourceCompletionProposal{obj}
+}
+
+// GetLabel is a wrapper around gtk_source_completion_proposal_get_label().
+func (v *SourceCompletionProposal) GetLabel() string {
+	return goStringFree(C.gtk_source_completion_proposal_get_label(v.native()))
+}
+
+// GetMarkup is a wrapper around gtk_source_completion_proposal_get_markup().
+func (v *SourceCompletionProposal) GetMarkup() string {
+	return goStringFree(C.gtk_source_completion_proposal_get_markup(v.native()))
+}
+
+// GetText is a wrapper around gtk_source_completion_proposal_get_text().
+func (v *SourceCompletionProposal) GetText() string {
+	return goStringFree(C.gtk_source_completion_proposal_get_text(v.native()))
+}
+
+// GetIcon is a wrapper around gtk_source_completion_proposal_get_icon().
+func (v *SourceCompletionProposal) GetIcon() *gdk.Pixbuf {
+	c := C.gtk_source_completion_proposal_get_icon(v.native())
+	if c == nil {
+		return nil
+	}
+	return &gdk.Pixbuf{glib.Take(unsafe.Pointer(c))}
+}
+
+// GetIconName is a wrapper around gtk_source_completion_proposal_get_icon_name().
+func (v *SourceCompletionProposal) GetIconName() string {
+	c := C.gtk_source_completion_proposal_get_icon_name(v.native())
+	if c == nil {
+		return ""
+	}
+	return goString(c)
+}
+
+// GetGIcon is a wrapper around gtk_source_completion_proposal_get_gicon().
+func (v *SourceCompletionProposal) GetGIcon() *glib.Object {
+	c := C.gtk_source_completion_proposal_get_gicon(v.native())
+	if c == nil {
+		return nil
+	}
+	return glib.Take(unsafe.Pointer(c))
+}
+
+// GetInfo is a wrapper around gtk_source_completion_proposal_get_info().
+func (v *SourceCompletionProposal) GetInfo() string {
+	return goStringFree(C.gtk_source_completion_proposal_get_info(v.native()))
+}
+
+/*
+ * GtkSourceCompletionProvider
+ */
+
+// ISourceCompletionProvider is an interface type implemented by all structs
+// embedding a GtkSourceCompletionProvider. It is meant to be used as an
+// argument type for wrapper functions that wrap around a C function taking a
+// GtkSourceCompletionProvider.
+type ISourceCompletionProvider interface {
+	toSourceCompletionProvider() *C.GtkSourceCompletionProvider
+}
+
+// SourceCompletionProvider is a representation of GtkSourceView's
+// GtkSourceCompletionProvider GInterface.
+type SourceCompletionProvider struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GObject as a GtkSourceCompletionProvider.
+func (v *SourceCompletionProvider) native() *C.GtkSourceCompletionProvider {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceCompletionProvider(p)
+}
+
+func (v *SourceCompletionProvider) toSourceCompletionProvider() *C.GtkSourceCompletionProvider {
+	if v == nil {
+		return nil
+	}
+	return v.native()
+}
+
+func marshalSourceCompletionProvider(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceCompletionProvider(obj), nil
+}
+
+func wrapSourceCompletionProvider(obj *glib.Object) *SourceCompletionProvider {
+	return &SourceCompletionProvider{obj}
+}
+
+// GetName is a wrapper around gtk_source_completion_provider_get_name().
+func (v *SourceCompletionProvider) GetName() string {
+	c := C.gtk_source_completion_provider_get_name(v.native())
+	if c == nil {
+		return ""
+	}
+	defer C.g_free(C.gpointer(c))
+	return goString(c)
+}
+
+// GetPriority is a wrapper around gtk_source_completion_provider_get_priority().
+func (v *SourceCompletionProvider) GetPriority() int {
+	return int(C.gtk_source_completion_provider_get_priority(v.native()))
+}
+
+// CompletionProvider is the interface implemented by Go completion providers.
+// Its methods are the virtual functions of GtkSourceCompletionProviderIface;
+// use SourceCompletionProviderNew to turn an implementation into a
+// GtkSourceCompletionProvider that can be added to a SourceCompletion.
+type CompletionProvider interface {
+	// GetName returns the name shown in the completion window.
+	GetName() string
+
+	// GetIcon returns the icon shown next to the name, or nil. The provider
+	// must keep the returned pixbuf alive.
+	GetIcon() *gdk.Pixbuf
+
+	// Populate adds proposals for the given context.
+	Populate(context *SourceCompletionContext)
+
+	// GetActivation returns when the provider should be activated.
+	GetActivation() SourceCompletionActivation
+
+	// ActivateProposal is called when proposal is selected, with iter at
+	// the cursor position. It returns false to let the default handler
+	// insert the proposal text.
+	ActivateProposal(proposal *SourceCompletionProposal, iter *gtk.TextIter) bool
+
+	// GetInteractiveDelay returns the delay in milliseconds before an
+	// interactive completion is started, or -1 for the default.
+	GetInteractiveDelay() int
+
+	// GetPriority returns the provider priority. Providers with a higher
+	// priority are listed first.
+	GetPriority() int
+}
+
+var errNilCompletionProvider = errors.New("completion provider is nil")
+
+var completionProviderRegistry = struct {
+	sync.RWMutex
+	next int
+	m    map[int]CompletionProvider
+}{
+	next: 1,
+	m:    make(map[int]CompletionProvider),
+}
+
+// SourceCompletionProviderNew creates a GtkSourceCompletionProvider whose
+// virtual functions are implemented by provider. provider is released when
+// the returned object is finalized.
+func SourceCompletionProviderNew(provider CompletionProvider) (*SourceCompletionProvider, error) {
+	if provider == nil {
+		return nil, errNilCompletionProvider
+	}
+
+	completionProviderRegistry.Lock()
+	id := completionProviderRegistry.next
+	completionProviderRegistry.next++
+	completionProviderRegistry.m[id] = provider
+	completionProviderRegistry.Unlock()
+
+	c := C.go_source_completion_provider_new(C.gint(id))
+	if c == nil {
+		completionProviderRegistry.Lock()
+		delete(completionProviderRegistry.m, id)
+		completionProviderRegistry.Unlock()
+		return nil, errNilPtr
+	}
+	// glib.Take adds a reference of its own. Drop the one returned by
+	// g_object_new so the object, and with it the registry entry, is
+	// released once it is no longer used from Go or C.
+	obj := glib.Take(unsafe.Pointer(c))
+	C.g_object_unref(C.gpointer(unsafe.Pointer(c)))
+	return wrapSourceCompletionProvider(obj), nil
+}
+
+// lookupCompletionProvider returns the Go provider registered under handle.
+func lookupCompletionProvider(handle C.gint) CompletionProvider {
+	completionProviderRegistry.RLock()
+	defer completionProviderRegistry.RUnlock()
+	return completionProviderRegistry.m[int(handle)]
+}
+
+/*
+ * GtkSourceCompletionWords
+ */
+
+// SourceCompletionWords is a representation of GtkSourceCompletionWords, a
+// completion provider proposing the words of the buffers registered with it.
+type SourceCompletionWords struct {
+	*glib.Object
+
+	SourceCompletionProvider
+}
+
+// native returns a pointer to the underlying GtkSourceCompletionWords.
+func (v *SourceCompletionWords) native() *C.GtkSourceCompletionWords {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceCompletionWords(p)
+}
+
+func marshalSourceCompletionWords(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceCompletionWords(obj), nil
+}
+
+func wrapSourceCompletionWords(obj *glib.Object) *SourceCompletionWords {
+	provider := wrapSourceCompletionProvider(obj)
+	return &SourceCompletionWords{obj, *provider}
+}
+
+// SourceCompletionWordsNew is a wrapper around gtk_source_completion_words_new().
+// icon may be nil.
+func SourceCompletionWordsNew(name string, icon *gdk.Pixbuf) (*SourceCompletionWords, error) {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	c := C.gtk_source_completion_words_new((*C.gchar)(cstr), nativePixbuf(icon))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceCompletionWords(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// Register is a wrapper around gtk_source_completion_words_register().
+func (v *SourceCompletionWords) Register(buffer *gtk.TextBuffer) {
+	C.gtk_source_completion_words_register(v.native(), C.toGtkTextBuffer(unsafe.Pointer(buffer.GObject)))
+}
+
+// Unregister is a wrapper around gtk_source_completion_words_unregister().
+func (v *SourceCompletionWords) Unregister(buffer *gtk.TextBuffer) {
+	C.gtk_source_completion_words_unregister(v.native(), C.toGtkTextBuffer(unsafe.Pointer(buffer.GObject)))
+}
+
+// getUint returns the value of the guint property name.
+func (v *SourceCompletionWords) getUint(name string) uint {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	return uint(C._g_object_get_uint(C.gpointer(unsafe.Pointer(v.GObject)), (*C.gchar)(cstr)))
+}
+
+// setUint sets the guint property name to value.
+func (v *SourceCompletionWords) setUint(name string, value uint) {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	C._g_object_set_uint(C.gpointer(unsafe.Pointer(v.GObject)), (*C.gchar)(cstr), C.guint(value))
+}
+
+// GetMinimumWordSize returns the "minimum-word-size" property.
+func (v *SourceCompletionWords) GetMinimumWordSize() uint {
+	return v.getUint("minimum-word-size")
+}
+
+// SetMinimumWordSize sets the "minimum-word-size" property, the minimum
+// length of a word to be proposed.
+func (v *SourceCompletionWords) SetMinimumWordSize(size uint) {
+	v.setUint("minimum-word-size", size)
+}
+
+// GetProposalsBatchSize returns the "proposals-batch-size" property.
+func (v *SourceCompletionWords) GetProposalsBatchSize() uint {
+	return v.getUint("proposals-batch-size")
+}
+
+// SetProposalsBatchSize sets the "proposals-batch-size" property, the number
+// of proposals added in one batch while populating.
+func (v *SourceCompletionWords) SetProposalsBatchSize(size uint) {
+	v.setUint("proposals-batch-size", size)
+}
+
+// GetScanBatchSize returns the "scan-batch-size" property.
+func (v *SourceCompletionWords) GetScanBatchSize() uint {
+	return v.getUint("scan-batch-size")
+}
+
+// SetScanBatchSize sets the "scan-batch-size" property, the number of lines
+// scanned in one batch while collecting words.
+func (v *SourceCompletionWords) SetScanBatchSize(size uint) {
+	v.setUint("scan-batch-size", size)
+}
+
+// GetActivation returns the "activation" property.
+func (v *SourceCompletionWords) GetActivation() SourceCompletionActivation {
+	return SourceCompletionActivation(v.getUint("activation"))
+}
+
+// SetActivation sets the "activation" property.
+func (v *SourceCompletionWords) SetActivation(activation SourceCompletionActivation) {
+	v.setUint("activation", uint(activation))
+}
@@ -0,0 +1,158 @@
+static GtkSourceCompletion *
+toGtkSourceCompletion(void *p)
+{
+	return (GTK_SOURCE_COMPLETION(p));
+}
+
+static GtkSourceCompletionContext *
+toGtkSourceCompletionContext(void *p)
+{
+	return (GTK_SOURCE_COMPLETION_CONTEXT(p));
+}
+
+static GtkSourceCompletionInfo *
+toGtkSourceCompletionInfo(void *p)
+{
+	return (GTK_SOURCE_COMPLETION_INFO(p));
+}
+
+static GtkSourceCompletionItem *
+toGtkSourceCompletionItem(void *p)
+{
+	return (GTK_SOURCE_COMPLETION_ITEM(p));
+}
+
+static GtkSourceCompletionWords *
+toGtkSourceCompletionWords(void *p)
+{
+	return (GTK_SOURCE_COMPLETION_WORDS(p));
+}
+
+static GtkSourceCompletionProposal *
+toGtkSourceCompletionProposal(void *p)
+{
+	return (GTK_SOURCE_COMPLETION_PROPOSAL(p));
+}
+
+static GtkSourceCompletionProvider *
+toGtkSourceCompletionProvider(void *p)
+{
+	return (GTK_SOURCE_COMPLETION_PROVIDER(p));
+}
+
+/*
+ * GoSourceCompletionProvider is a GObject implementing
+ * GtkSourceCompletionProvider by forwarding every virtual function to the Go
+ * CompletionProvider registered under its handle.
+ */
+
+extern gchar *goCompletionProviderGetName(gint handle);
+extern GdkPixbuf *goCompletionProviderGetIcon(gint handle);
+extern void goCompletionProviderPopulate(gint handle, GtkSourceCompletionContext *context);
+extern GtkSourceCompletionActivation goCompletionProviderGetActivation(gint handle);
+extern gboolean goCompletionProviderActivateProposal(gint handle, GtkSourceCompletionProposal *proposal, GtkTextIter *iter);
+extern gint goCompletionProviderGetInteractiveDelay(gint handle);
+extern gint goCompletionProviderGetPriority(gint handle);
+extern void goCompletionProviderFinalize(gint handle);
+
+typedef struct {
+	GObject parent_instance;
+	gint handle;
+} GoSourceCompletionProvider;
+
+typedef struct {
+	GObjectClass parent_class;
+} GoSourceCompletionProviderClass;
+
+static void go_source_completion_provider_iface_init(GtkSourceCompletionProviderIface *iface);
+
+G_DEFINE_TYPE_WITH_CODE(GoSourceCompletionProvider, go_source_completion_provider, G_TYPE_OBJECT,
+	G_IMPLEMENT_INTERFACE(GTK_SOURCE_TYPE_COMPLETION_PROVIDER,
+		go_source_completion_provider_iface_init))
+
+#define GO_SOURCE_COMPLETION_PROVIDER_HANDLE(p) (((GoSourceCompletionProvider *)(p))->handle)
+
+static gchar *
+go_source_completion_provider_get_name(GtkSourceCompletionProvider *provider)
+{
+	return goCompletionProviderGetName(GO_SOURCE_COMPLETION_PROVIDER_HANDLE(provider));
+}
+
+static GdkPixbuf *
+go_source_completion_provider_get_icon(GtkSourceCompletionProvider *provider)
+{
+	return goCompletionProviderGetIcon(GO_SOURCE_COMPLETION_PROVIDER_HANDLE(provider));
+}
+
+static void
+go_source_completion_provider_populate(GtkSourceCompletionProvider *provider,
+	GtkSourceCompletionContext *context)
+{
+	goCompletionProviderPopulate(GO_SOURCE_COMPLETION_PROVIDER_HANDLE(provider), context);
+}
+
+static GtkSourceCompletionActivation
+go_source_completion_provider_get_activation(GtkSourceCompletionProvider *provider)
+{
+	return goCompletionProviderGetActivation(GO_SOURCE_COMPLETION_PROVIDER_HANDLE(provider));
+}
+
+static gboolean
+go_source_completion_provider_activate_proposal(GtkSourceCompletionProvider *provider,
+	GtkSourceCompletionProposal *proposal, GtkTextIter *iter)
+{
+	return goCompletionProviderActivateProposal(GO_SOURCE_COMPLETION_PROVIDER_HANDLE(provider),
+		proposal, iter);
+}
+
+static gint
+go_source_completion_provider_get_interactive_delay(GtkSourceCompletionProvider *provider)
+{
+	return goCompletionProviderGetInteractiveDelay(GO_SOURCE_COMPLETION_PROVIDER_HANDLE(provider));
+}
+
+static gint
+go_source_completion_provider_get_priority(GtkSourceCompletionProvider *provider)
+{
+	return goCompletionProviderGetPriority(GO_SOURCE_COMPLETION_PROVIDER_HANDLE(provider));
+}
+
+static void
+go_source_completion_provider_finalize(GObject *object)
+{
+	goCompletionProviderFinalize(GO_SOURCE_COMPLETION_PROVIDER_HANDLE(object));
+	G_OBJECT_CLASS(go_source_completion_provider_parent_class)->finalize(object);
+}
+
+static void
+go_source_completion_provider_class_init(GoSourceCompletionProviderClass *klass)
+{
+	G_OBJECT_CLASS(klass)->finalize = go_source_completion_provider_finalize;
+}
+
+static void
+go_source_completion_provider_init(GoSourceCompletionProvider *self)
+{
+}
+
+static void
+go_source_completion_provider_iface_init(GtkSourceCompletionProviderIface *iface)
+{
+	iface->get_name = go_source_completion_provider_get_name;
+	iface->get_icon = go_source_completion_provider_get_icon;
+	iface->populate = go_source_completion_provider_populate;
+	iface->get_activation = go_source_completion_provider_get_activation;
+	iface->activate_proposal = go_source_completion_provider_activate_proposal;
+	iface->get_interactive_delay = go_source_completion_provider_get_interactive_delay;
+	iface->get_priority = go_source_completion_provider_get_priority;
+}
+
+static GtkSourceCompletionProvider *
+go_source_completion_provider_new(gint handle)
+{
+	GoSourceCompletionProvider *provider;
+
+	provider = g_object_new(go_source_completion_provider_get_type(), NULL);
+	provider->handle = handle;
+	return GTK_SOURCE_COMPLETION_PROVIDER(provider);
+}
@@ -0,0 +1,137 @@
+package sourceview
+
+// #cgo pkg-config: gtksourceview-3.0
+// #include <gtksourceview/gtksourcebuffer.h>
+// #include "sourceview.go.h"
+import "C"
+import (
+	"unsafe"
+
+	"github.com/gotk3/gotk3/gtk"
+)
+
+// Context classes defined by the default language definitions.
+const (
+	CONTEXT_CLASS_COMMENT        = "comment"
+	CONTEXT_CLASS_STRING         = "string"
+	CONTEXT_CLASS_NO_SPELL_CHECK = "no-spell-check"
+)
+
+// IterHasContextClass is a wrapper around gtk_source_buffer_iter_has_context_class().
+func (v *SourceBuffer) IterHasContextClass(iter *gtk.TextIter, contextClass string) bool {
+	cstr := C.CString(contextClass)
+	defer C.free(unsafe.Pointer(cstr))
+	return gobool(C.gtk_source_buffer_iter_has_context_class(v.native(),
+		nativeTextIter(iter), (*C.gchar)(cstr)))
+}
+
+// GetContextClassesAtIter is a wrapper around gtk_source_buffer_get_context_classes_at_iter().
+func (v *SourceBuffer) GetContextClassesAtIter(iter *gtk.TextIter) []string {
+	c := C.gtk_source_buffer_get_context_classes_at_iter(v.native(),
+		nativeTextIter(iter))
+	return goStringsFree(c)
+}
+
+// IterForwardToContextClassToggle is a wrapper around
+// gtk_source_buffer_iter_forward_to_context_class_toggle(). It moves iter
+// forward to the next position where contextClass starts or ends, and
+// returns false if there is none.
+func (v *SourceBuffer) IterForwardToContextClassToggle(iter *gtk.TextIter, contextClass string) bool {
+	cstr := C.CString(contextClass)
+	defer C.free(unsafe.Pointer(cstr))
+	return gobool(C.gtk_source_buffer_iter_forward_to_context_class_toggle(
+		v.native(), nativeTextIter(iter), (*C.gchar)(cstr)))
+}
+
+// IterBackwardToContextClassToggle is a wrapper around
+// gtk_source_buffer_iter_backward_to_context_class_toggle(). It moves iter
+// backward to the previous position where contextClass starts or ends, and
+// returns false if there is none.
+func (v *SourceBuffer) IterBackwardToContextClassToggle(iter *gtk.TextIter, contextClass string) bool {
+	cstr := C.CString(contextClass)
+	defer C.free(unsafe.Pointer(cstr))
+	return gobool(C.gtk_source_buffer_iter_backward_to_context_class_toggle(
+		v.native(), nativeTextIter(iter), (*C.gchar)(cstr)))
+}
+
+// ContextClassSpans iterates over the regions of a SourceBuffer covered by
+// the CONTEXT_CLASS_COMMENT, CONTEXT_CLASS_STRING and
+// CONTEXT_CLASS_NO_SPELL_CHECK context classes. Each span is a maximal range
+// over which the set of those classes does not change; text outside all
+// three classes is skipped.
+//
+// Context classes are only known for highlighted text, so call
+// SourceBuffer.EnsureHighlight on the range first. The buffer must not be
+// modified while iterating.
+//
+//	spans := buffer.ContextClassSpans(start, end)
+//	for spans.Next() {
+//		start, end, classes := spans.Span()
+//		...
+//	}
+type ContextClassSpans struct {
+	buffer     *SourceBuffer
+	pos, limit gtk.TextIter
+
+	start, end gtk.TextIter
+	classes    []string
+}
+
+var spanContextClasses = []string{
+	CONTEXT_CLASS_COMMENT,
+	CONTEXT_CLASS_STRING,
+	CONTEXT_CLASS_NO_SPELL_CHECK,
+}
+
+// ContextClassSpans returns an iterator over the context class spans
+// between start and end.
+func (v *SourceBuffer) ContextClassSpans(start, end *gtk.TextIter) *ContextClassSpans {
+	s := &ContextClassSpans{buffer: v, pos: *start, limit: *end}
+	if s.pos.Compare(&s.limit) > 0 {
+		s.pos, s.limit = s.limit, s.pos
+	}
+	return s
+}
+
+// Next advances to the next span and reports whether there is one.
+func (s *ContextClassSpans) Next() bool {
+	for s.pos.Compare(&s.limit) < 0 {
+		start := s.pos
+		classes := s.classesAt(&start)
+
+		// The span ends at the nearest toggle of any tracked class.
+		next := s.limit
+		for _, class := range spanContextClasses {
+			iter := start
+			if s.buffer.IterForwardToContextClassToggle(&iter, class) &&
+				iter.Compare(&next) < 0 {
+				next = iter
+			}
+		}
+		s.pos = next
+
+		if len(classes) > 0 {
+			s.start, s.end, s.classes = start, next, classes
+			return true
+		}
+	}
+	return false
+}
+
+// Span returns the bounds and context classes of the current span. The
+// iterators are copies owned by the caller.
+func (s *ContextClassSpans) Span() (start, end *gtk.TextIter, classes []string) {
+	startCopy, endCopy := s.start, s.end
+	return &startCopy, &endCopy, s.classes
+}
+
+// classesAt returns the tracked context classes present at iter.
+func (s *ContextClassSpans) classesAt(iter *gtk.TextIter) []string {
+	var classes []string
+	for _, class := range spanContextClasses {
+		if s.buffer.IterHasContextClass(iter, class) {
+			classes = append(classes, class)
+		}
+	}
+	return classes
+}
@@ -0,0 +1,119 @@
+package sourceview
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+var errLanguagesLoaded = errors.New("search path cannot be changed after languages have been loaded")
+
+// AddLanguagesFromFS copies the .lang files found in the directory dir of
+// fsys into a cache directory and prepends it to the search path, so that
+// they take precedence over installed language definitions. The cache
+// directory is kept and reused by later runs embedding the same files.
+//
+// It must be called before any language is requested from v: the search
+// path of a SourceLanguageManager cannot change once its languages are
+// loaded. Otherwise GTK logs a critical warning, the search path is left
+// unchanged and an error is returned.
+func (v *SourceLanguageManager) AddLanguagesFromFS(fsys fs.FS, dir string) error {
+	cacheDir, err := materializeFS(fsys, dir, ".lang", "languages")
+	if err != nil {
+		return err
+	}
+
+	v.SetSearchPath(append([]string{cacheDir}, v.GetSearchPath()...))
+	if paths := v.GetSearchPath(); len(paths) == 0 || paths[0] != cacheDir {
+		return errLanguagesLoaded
+	}
+	return nil
+}
+
+// AddSchemesFromFS copies the .xml files found in the directory dir of fsys
+// into a cache directory and prepends it to the search path, so that they
+// take precedence over installed style schemes. The cache directory is kept
+// and reused by later runs embedding the same files.
+func (v *SourceStyleSchemeManager) AddSchemesFromFS(fsys fs.FS, dir string) error {
+	cacheDir, err := materializeFS(fsys, dir, ".xml", "styles")
+	if err != nil {
+		return err
+	}
+
+	v.PrependSearchPath(cacheDir)
+	return nil
+}
+
+// materializeFS copies the regular files with extension ext in the directory
+// dir of fsys to a directory of the user cache directory and returns its
+// path. The directory is named after kind and a hash of the copied files,
+// so it is shared by every run embedding the same files and left in place
+// for the next one.
+//
+// Directories for other sets of files are not removed, as they may belong to
+// another program or version still using them; a new directory is created
+// each time the embedded files change. They stay on disk until the user
+// cache is cleaned, and may be deleted whenever no program using them runs.
+func materializeFS(fsys fs.FS, dir, ext, kind string) (string, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return "", err
+	}
+
+	files := make(map[string][]byte)
+	h := sha256.New()
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || path.Ext(entry.Name()) != ext {
+			continue
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return "", err
+		}
+		files[entry.Name()] = data
+		// Entries are sorted by name, so the hash does not depend on the
+		// order fsys lists them in.
+		h.Write([]byte(entry.Name()))
+		h.Write([]byte{0})
+		h.Write(data)
+		h.Write([]byte{0})
+	}
+
+	cacheRoot, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	cacheRoot = filepath.Join(cacheRoot, "gotk3-sourceview")
+	if err := os.MkdirAll(cacheRoot, 0700); err != nil {
+		return "", err
+	}
+	cacheDir := filepath.Join(cacheRoot, kind+"-"+hex.EncodeToString(h.Sum(nil)[:16]))
+	if _, err := os.Stat(cacheDir); err == nil {
+		return cacheDir, nil
+	}
+
+	// Fill a temporary directory and rename it into place, so that a
+	// concurrent run never sees a partially written cache directory.
+	tmpDir, err := os.MkdirTemp(cacheRoot, kind+"-tmp-")
+	if err != nil {
+		return "", err
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), data, 0600); err != nil {
+			os.RemoveAll(tmpDir)
+			return "", err
+		}
+	}
+	if err := os.Rename(tmpDir, cacheDir); err != nil {
+		os.RemoveAll(tmpDir)
+		// Another run may have created the same directory first.
+		if _, statErr := os.Stat(cacheDir); statErr != nil {
+			return "", err
+		}
+	}
+	return cacheDir, nil
+}
@@ -0,0 +1,118 @@
+package sourceview
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+	"testing/fstest"
+)
+
+func readDirNames(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestMaterializeFS(t *testing.T) {
+	cache := t.TempDir()
+	t.Setenv("XDG_CACHE_HOME", cache)
+
+	fsys := fstest.MapFS{
+		"specs/a.lang":       {Data: []byte("a")},
+		"specs/b.lang":       {Data: []byte("b")},
+		"specs/scheme.xml":   {Data: []byte("x")},
+		"specs/README":       {Data: []byte("r")},
+		"specs/sub/c.lang":   {Data: []byte("c")},
+		"other/ignored.lang": {Data: []byte("i")},
+	}
+
+	dir, err := materializeFS(fsys, "specs", ".lang", "languages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := filepath.Dir(dir), filepath.Join(cache, "gotk3-sourceview"); got != want {
+		t.Errorf("directory %s is not in %s", dir, want)
+	}
+	if got, want := readDirNames(t, dir), []string{"a.lang", "b.lang"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("copied %q, want %q", got, want)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, "b.lang")); err != nil || string(data) != "b" {
+		t.Errorf("b.lang = %q, %v", data, err)
+	}
+
+	again, err := materializeFS(fsys, "specs", ".lang", "languages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != dir {
+		t.Errorf("same files materialized to %s, then %s", dir, again)
+	}
+
+	schemes, err := materializeFS(fsys, "specs", ".xml", "styles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := readDirNames(t, schemes), []string{"scheme.xml"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("copied %q, want %q", got, want)
+	}
+
+	fsys["specs/b.lang"] = &fstest.MapFile{Data: []byte("changed")}
+	changed, err := materializeFS(fsys, "specs", ".lang", "languages")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if changed == dir {
+		t.Errorf("changed files materialized to the same directory %s", dir)
+	}
+	if data, err := os.ReadFile(filepath.Join(changed, "b.lang")); err != nil || string(data) != "changed" {
+		t.Errorf("b.lang = %q, %v", data, err)
+	}
+
+	want := []string{filepath.Base(changed), filepath.Base(dir), filepath.Base(schemes)}
+	sort.Strings(want)
+	if got := readDirNames(t, filepath.Dir(dir)); !reflect.DeepEqual(got, want) {
+		t.Errorf("cache holds %q, want %q", got, want)
+	}
+}
+
+func TestMaterializeFSConcurrent(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	fsys := fstest.MapFS{"specs/a.lang": {Data: []byte("a")}}
+
+	const n = 8
+	dirs := make([]string, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			dirs[i], errs[i] = materializeFS(fsys, "specs", ".lang", "languages")
+		}(i)
+	}
+	wg.Wait()
+
+	for i := range dirs {
+		if errs[i] != nil {
+			t.Fatal(errs[i])
+		}
+		if dirs[i] != dirs[0] {
+			t.Errorf("concurrent calls materialized to %s and %s", dirs[0], dirs[i])
+		}
+	}
+	// Temporary directories losing the race must be removed.
+	if got := readDirNames(t, filepath.Dir(dirs[0])); len(got) != 1 {
+		t.Errorf("cache holds %q, want only %s", got, filepath.Base(dirs[0]))
+	}
+}
@@ -0,0 +1,106 @@
+package sourceview
+
+// #cgo pkg-config: gtksourceview-3.0
+// #include <gtksourceview/gtksourceencoding.h>
+// #include "sourceview.go.h"
+import "C"
+import (
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+)
+
+func init() {
+	tm := []glib.TypeMarshaler{
+		{glib.Type(C.gtk_source_encoding_get_type()), marshalSourceEncoding},
+	}
+	glib.RegisterGValueMarshalers(tm)
+}
+
+/*
+ * GtkSourceEncoding
+ */
+
+// SourceEncoding is a representation of GtkSourceEncoding. Encodings are
+// static and never need to be freed.
+type SourceEncoding struct {
+	encoding *C.GtkSourceEncoding
+}
+
+// native returns a pointer to the underlying GtkSourceEncoding.
+func (v *SourceEncoding) native() *C.GtkSourceEncoding {
+	if v == nil {
+		return nil
+	}
+	return v.encoding
+}
+
+func marshalSourceEncoding(p uintptr) (interface{}, error) {
+	c := C.g_value_get_boxed((*C.GValue)(unsafe.Pointer(p)))
+	return wrapSourceEncoding((*C.GtkSourceEncoding)(unsafe.Pointer(c))), nil
+}
+
+func wrapSourceEncoding(encoding *C.GtkSourceEncoding) *SourceEncoding {
+	if encoding == nil {
+		return nil
+	}
+	return &SourceEncoding{encoding}
+}
+
+// Charset is a wrapper around gtk_source_encoding_get_charset().
+func (v *SourceEncoding) Charset() string {
+	return goString(C.gtk_source_encoding_get_charset(v.native()))
+}
+
+// Name is a wrapper around gtk_source_encoding_get_name().
+func (v *SourceEncoding) Name() string {
+	return goString(C.gtk_source_encoding_get_name(v.native()))
+}
+
+// String is a wrapper around gtk_source_encoding_to_string().
+func (v *SourceEncoding) String() string {
+	return goStringFree(C.gtk_source_encoding_to_string(v.native()))
+}
+
+// goSourceEncodings converts list, a GSList of GtkSourceEncodings, to a slice
+// and frees the list.
+func goSourceEncodings(list *C.GSList) []*SourceEncoding {
+	defer C.g_slist_free(list)
+
+	var encodings []*SourceEncoding
+	for l := list; l != nil; l = l.next {
+		encodings = append(encodings, wrapSourceEncoding((*C.GtkSourceEncoding)(unsafe.Pointer(l.data))))
+	}
+	return encodings
+}
+
+// SourceEncodingGetAll is a wrapper around gtk_source_encoding_get_all().
+func SourceEncodingGetAll() []*SourceEncoding {
+	return goSourceEncodings(C.gtk_source_encoding_get_all())
+}
+
+// SourceEncodingGetDefaultCandidates is a wrapper around gtk_source_encoding_get_default_candidates().
+// The candidates are ordered by preference and depend on the current locale.
+func SourceEncodingGetDefaultCandidates() []*SourceEncoding {
+	return goSourceEncodings(C.gtk_source_encoding_get_default_candidates())
+}
+
+// SourceEncodingGetFromCharset is a wrapper around gtk_source_encoding_get_from_charset().
+// It returns nil if charset is unknown. Charsets are matched case
+// insensitively, so "windows-1252" and "SHIFT_JIS" are both found.
+func SourceEncodingGetFromCharset(charset string) *SourceEncoding {
+	cstr := C.CString(charset)
+	defer C.free(unsafe.Pointer(cstr))
+	return wrapSourceEncoding(C.gtk_source_encoding_get_from_charset((*C.gchar)(cstr)))
+}
+
+// SourceEncodingGetUTF8 is a wrapper around gtk_source_encoding_get_utf8().
+func SourceEncodingGetUTF8() *SourceEncoding {
+	return wrapSourceEncoding(C.gtk_source_encoding_get_utf8())
+}
+
+// SourceEncodingGetCurrent is a wrapper around gtk_source_encoding_get_current().
+// It returns the encoding of the current locale.
+func SourceEncodingGetCurrent() *SourceEncoding {
+	return wrapSourceEncoding(C.gtk_source_encoding_get_current())
+}
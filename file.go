@@ -0,0 +1,516 @@
+package sourceview
+
+// #cgo pkg-config: gtksourceview-3.0
+// #include <gtksourceview/gtksourcebuffer.h>
+// #include <gtksourceview/gtksourceencoding.h>
+// #include <gtksourceview/gtksourcefile.h>
+// #include <gtksourceview/gtksourcefileloader.h>
+// #include <gtksourceview/gtksourcefilesaver.h>
+// #include <gtksourceview/gtksourceview-typebuiltins.h>
+// #include "sourceview.go.h"
+// #include "file.go.h"
+import "C"
+import (
+	"context"
+	"errors"
+	"sync"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+var (
+	// ErrInvalidChars matches, with errors.Is, the error of
+	// SourceFileSaver.SaveAsync when the buffer contains invalid characters
+	// and SOURCE_FILE_SAVER_FLAGS_IGNORE_INVALID_CHARS is not set.
+	ErrInvalidChars = errors.New("buffer contains invalid characters")
+
+	// ErrExternallyModified matches, with errors.Is, the error of
+	// SourceFileSaver.SaveAsync when the file was modified by another program
+	// since it was loaded and SOURCE_FILE_SAVER_FLAGS_IGNORE_MODIFICATION_TIME
+	// is not set.
+	ErrExternallyModified = errors.New("file was externally modified")
+)
+
+func init() {
+	tm := []glib.TypeMarshaler{
+		// Enums
+		{glib.Type(C.gtk_source_compression_type_get_type()), marshalCompressionType},
+		{glib.Type(C.gtk_source_file_saver_flags_get_type()), marshalSourceFileSaverFlags},
+		{glib.Type(C.gtk_source_newline_type_get_type()), marshalNewlineType},
+
+		// Objects/Interfaces
+		{glib.Type(C.gtk_source_file_get_type()), marshalSourceFile},
+		{glib.Type(C.gtk_source_file_loader_get_type()), marshalSourceFileLoader},
+		{glib.Type(C.gtk_source_file_saver_get_type()), marshalSourceFileSaver},
+	}
+	glib.RegisterGValueMarshalers(tm)
+
+	gtk.WrapMap["GtkSourceFile"] = wrapSourceFile
+	gtk.WrapMap["GtkSourceFileLoader"] = wrapSourceFileLoader
+	gtk.WrapMap["GtkSourceFileSaver"] = wrapSourceFileSaver
+}
+
+/*
+ * Constants
+ */
+
+// CompressionType is a representation of GtkSourceCompressionType.
+type CompressionType int
+
+const (
+	COMPRESSION_TYPE_NONE CompressionType = C.GTK_SOURCE_COMPRESSION_TYPE_NONE
+	COMPRESSION_TYPE_GZIP CompressionType = C.GTK_SOURCE_COMPRESSION_TYPE_GZIP
+)
+
+func marshalCompressionType(p uintptr) (interface{}, error) {
+	c := C.g_value_get_enum((*C.GValue)(unsafe.Pointer(p)))
+	return CompressionType(c), nil
+}
+
+// NewlineType is a representation of GtkSourceNewlineType.
+type NewlineType int
+
+const (
+	NEWLINE_TYPE_LF    NewlineType = C.GTK_SOURCE_NEWLINE_TYPE_LF
+	NEWLINE_TYPE_CR    NewlineType = C.GTK_SOURCE_NEWLINE_TYPE_CR
+	NEWLINE_TYPE_CR_LF NewlineType = C.GTK_SOURCE_NEWLINE_TYPE_CR_LF
+)
+
+func marshalNewlineType(p uintptr) (interface{}, error) {
+	c := C.g_value_get_enum((*C.GValue)(unsafe.Pointer(p)))
+	return NewlineType(c), nil
+}
+
+// SourceFileSaverFlags is a representation of GtkSourceFileSaverFlags.
+type SourceFileSaverFlags int
+
+const (
+	SOURCE_FILE_SAVER_FLAGS_NONE                     SourceFileSaverFlags = C.GTK_SOURCE_FILE_SAVER_FLAGS_NONE
+	SOURCE_FILE_SAVER_FLAGS_IGNORE_INVALID_CHARS     SourceFileSaverFlags = C.GTK_SOURCE_FILE_SAVER_FLAGS_IGNORE_INVALID_CHARS
+	SOURCE_FILE_SAVER_FLAGS_IGNORE_MODIFICATION_TIME SourceFileSaverFlags = C.GTK_SOURCE_FILE_SAVER_FLAGS_IGNORE_MODIFICATION_TIME
+	SOURCE_FILE_SAVER_FLAGS_CREATE_BACKUP            SourceFileSaverFlags = C.GTK_SOURCE_FILE_SAVER_FLAGS_CREATE_BACKUP
+)
+
+func marshalSourceFileSaverFlags(p uintptr) (interface{}, error) {
+	c := C.g_value_get_flags((*C.GValue)(unsafe.Pointer(p)))
+	return SourceFileSaverFlags(c), nil
+}
+
+/*
+ * File progress callbacks
+ */
+
+// FileProgressFunc is called during an asynchronous file operation with the
+// number of bytes processed so far and the total number of bytes.
+type FileProgressFunc func(current, total int64)
+
+var fileProgressRegistry = struct {
+	sync.Mutex
+	next int
+	m    map[int]FileProgressFunc
+}{
+	next: 1,
+	m:    make(map[int]FileProgressFunc),
+}
+
+// registerFileProgress stores fn until goFileProgressDestroyNotify is called
+// with the returned user data. It returns nil if fn is nil.
+func registerFileProgress(fn FileProgressFunc) C.gpointer {
+	if fn == nil {
+		return nil
+	}
+	fileProgressRegistry.Lock()
+	id := fileProgressRegistry.next
+	fileProgressRegistry.next++
+	fileProgressRegistry.m[id] = fn
+	fileProgressRegistry.Unlock()
+	return C.gpointer(uintptr(id))
+}
+
+/*
+ * GtkSourceFile
+ */
+
+// SourceFile is a representation of GtkSourceFile.
+type SourceFile struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GtkSourceFile.
+func (v *SourceFile) native() *C.GtkSourceFile {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceFile(p)
+}
+
+func marshalSourceFile(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceFile(obj), nil
+}
+
+func wrapSourceFile(obj *glib.Object) *SourceFile {
+	return &SourceFile{obj}
+}
+
+// nativeGFile returns a pointer to the GFile underlying file, or nil.
+func nativeGFile(file *glib.File) *C.GFile {
+	if file == nil || file.Object == nil {
+		return nil
+	}
+	return (*C.GFile)(unsafe.Pointer(file.GObject))
+}
+
+// SourceFileNew is a wrapper around gtk_source_file_new().
+func SourceFileNew() (*SourceFile, error) {
+	c := C.gtk_source_file_new()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceFile(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetLocation is a wrapper around gtk_source_file_get_location().
+// It returns nil if no location is set.
+func (v *SourceFile) GetLocation() *glib.File {
+	c := C.gtk_source_file_get_location(v.native())
+	if c == nil {
+		return nil
+	}
+	return &glib.File{glib.Take(unsafe.Pointer(c))}
+}
+
+// SetLocation is a wrapper around gtk_source_file_set_location().
+func (v *SourceFile) SetLocation(location *glib.File) {
+	C.gtk_source_file_set_location(v.native(), nativeGFile(location))
+}
+
+// GetEncoding is a wrapper around gtk_source_file_get_encoding().
+// It returns nil until the file is loaded or saved.
+func (v *SourceFile) GetEncoding() *SourceEncoding {
+	return wrapSourceEncoding(C.gtk_source_file_get_encoding(v.native()))
+}
+
+// GetNewlineType is a wrapper around gtk_source_file_get_newline_type().
+func (v *SourceFile) GetNewlineType() NewlineType {
+	return NewlineType(C.gtk_source_file_get_newline_type(v.native()))
+}
+
+// GetCompressionType is a wrapper around gtk_source_file_get_compression_type().
+func (v *SourceFile) GetCompressionType() CompressionType {
+	return CompressionType(C.gtk_source_file_get_compression_type(v.native()))
+}
+
+// CheckFileOnDisk is a wrapper around gtk_source_file_check_file_on_disk().
+// It updates the values returned by IsLocal, IsExternallyModified, IsDeleted
+// and IsReadonly.
+func (v *SourceFile) CheckFileOnDisk() {
+	C.gtk_source_file_check_file_on_disk(v.native())
+}
+
+// IsLocal is a wrapper around gtk_source_file_is_local().
+func (v *SourceFile) IsLocal() bool {
+	return gobool(C.gtk_source_file_is_local(v.native()))
+}
+
+// IsExternallyModified is a wrapper around gtk_source_file_is_externally_modified().
+func (v *SourceFile) IsExternallyModified() bool {
+	return gobool(C.gtk_source_file_is_externally_modified(v.native()))
+}
+
+// IsDeleted is a wrapper around gtk_source_file_is_deleted().
+func (v *SourceFile) IsDeleted() bool {
+	return gobool(C.gtk_source_file_is_deleted(v.native()))
+}
+
+// IsReadonly is a wrapper around gtk_source_file_is_readonly().
+func (v *SourceFile) IsReadonly() bool {
+	return gobool(C.gtk_source_file_is_readonly(v.native()))
+}
+
+/*
+ * GtkSourceFileLoader
+ */
+
+// SourceFileLoader is a representation of GtkSourceFileLoader.
+type SourceFileLoader struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GtkSourceFileLoader.
+func (v *SourceFileLoader) native() *C.GtkSourceFileLoader {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceFileLoader(p)
+}
+
+func marshalSourceFileLoader(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceFileLoader(obj), nil
+}
+
+func wrapSourceFileLoader(obj *glib.Object) *SourceFileLoader {
+	return &SourceFileLoader{obj}
+}
+
+// SourceFileLoaderNew is a wrapper around gtk_source_file_loader_new().
+// The location of file must be set.
+func SourceFileLoaderNew(buffer *SourceBuffer, file *SourceFile) (*SourceFileLoader, error) {
+	c := C.gtk_source_file_loader_new(buffer.native(), file.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceFileLoader(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetBuffer is a wrapper around gtk_source_file_loader_get_buffer().
+func (v *SourceFileLoader) GetBuffer() (*SourceBuffer, error) {
+	c := C.gtk_source_file_loader_get_buffer(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceBuffer(glib.Take(unsafe.Pointer(c)))
+}
+
+// GetFile is a wrapper around gtk_source_file_loader_get_file().
+func (v *SourceFileLoader) GetFile() (*SourceFile, error) {
+	c := C.gtk_source_file_loader_get_file(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceFile(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetLocation is a wrapper around gtk_source_file_loader_get_location().
+func (v *SourceFileLoader) GetLocation() *glib.File {
+	c := C.gtk_source_file_loader_get_location(v.native())
+	if c == nil {
+		return nil
+	}
+	return &glib.File{glib.Take(unsafe.Pointer(c))}
+}
+
+// SetCandidateEncodings is a wrapper around gtk_source_file_loader_set_candidate_encodings().
+// Encodings are tried in order; by default the loader uses
+// SourceEncodingGetDefaultCandidates.
+func (v *SourceFileLoader) SetCandidateEncodings(encodings []*SourceEncoding) {
+	var list *C.GSList
+	for _, encoding := range encodings {
+		list = C.g_slist_prepend(list, C.gpointer(unsafe.Pointer(encoding.native())))
+	}
+	list = C.g_slist_reverse(list)
+	defer C.g_slist_free(list)
+
+	C.gtk_source_file_loader_set_candidate_encodings(v.native(), list)
+}
+
+// GetEncoding is a wrapper around gtk_source_file_loader_get_encoding().
+// It returns the encoding detected by the last successful load.
+func (v *SourceFileLoader) GetEncoding() *SourceEncoding {
+	return wrapSourceEncoding(C.gtk_source_file_loader_get_encoding(v.native()))
+}
+
+// GetNewlineType is a wrapper around gtk_source_file_loader_get_newline_type().
+// It returns the newline type detected by the last successful load.
+func (v *SourceFileLoader) GetNewlineType() NewlineType {
+	return NewlineType(C.gtk_source_file_loader_get_newline_type(v.native()))
+}
+
+// GetCompressionType is a wrapper around gtk_source_file_loader_get_compression_type().
+// It returns the compression type detected by the last successful load.
+func (v *SourceFileLoader) GetCompressionType() CompressionType {
+	return CompressionType(C.gtk_source_file_loader_get_compression_type(v.native()))
+}
+
+// LoadAsync is a wrapper around gtk_source_file_loader_load_async().
+// ioPriority is a GLib I/O priority, where 0 is G_PRIORITY_DEFAULT. progress
+// may be nil. callback runs on the GTK main loop once loading is done; it
+// receives ctx.Err() if ctx is done before loading completes.
+func (v *SourceFileLoader) LoadAsync(ctx context.Context, ioPriority int, progress FileProgressFunc, callback func(err error)) {
+	cancellable, release := cancellableNew(ctx)
+	data := registerAsyncReady(func(res *C.GAsyncResult) {
+		defer release()
+
+		var err *C.GError
+		C.gtk_source_file_loader_load_finish(v.native(), res, &err)
+		callback(asyncError(ctx, err))
+	})
+	C._gtk_source_file_loader_load_async(v.native(), C.gint(ioPriority), cancellable,
+		registerFileProgress(progress), data)
+}
+
+/*
+ * GtkSourceFileSaver
+ */
+
+// SourceFileSaver is a representation of GtkSourceFileSaver.
+type SourceFileSaver struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GtkSourceFileSaver.
+func (v *SourceFileSaver) native() *C.GtkSourceFileSaver {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceFileSaver(p)
+}
+
+func marshalSourceFileSaver(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceFileSaver(obj), nil
+}
+
+func wrapSourceFileSaver(obj *glib.Object) *SourceFileSaver {
+	return &SourceFileSaver{obj}
+}
+
+// SourceFileSaverNew is a wrapper around gtk_source_file_saver_new().
+// The buffer is saved to the location of file, which must be set.
+func SourceFileSaverNew(buffer *SourceBuffer, file *SourceFile) (*SourceFileSaver, error) {
+	c := C.gtk_source_file_saver_new(buffer.native(), file.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceFileSaver(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// SourceFileSaverNewWithTarget is a wrapper around gtk_source_file_saver_new_with_target().
+// The buffer is saved to targetLocation, which becomes the location of file
+// once saving succeeds.
+func SourceFileSaverNewWithTarget(buffer *SourceBuffer, file *SourceFile, targetLocation *glib.File) (*SourceFileSaver, error) {
+	c := C.gtk_source_file_saver_new_with_target(buffer.native(), file.native(), nativeGFile(targetLocation))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceFileSaver(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetBuffer is a wrapper around gtk_source_file_saver_get_buffer().
+func (v *SourceFileSaver) GetBuffer() (*SourceBuffer, error) {
+	c := C.gtk_source_file_saver_get_buffer(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceBuffer(glib.Take(unsafe.Pointer(c)))
+}
+
+// GetFile is a wrapper around gtk_source_file_saver_get_file().
+func (v *SourceFileSaver) GetFile() (*SourceFile, error) {
+	c := C.gtk_source_file_saver_get_file(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceFile(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetLocation is a wrapper around gtk_source_file_saver_get_location().
+func (v *SourceFileSaver) GetLocation() *glib.File {
+	c := C.gtk_source_file_saver_get_location(v.native())
+	if c == nil {
+		return nil
+	}
+	return &glib.File{glib.Take(unsafe.Pointer(c))}
+}
+
+// GetEncoding is a wrapper around gtk_source_file_saver_get_encoding().
+func (v *SourceFileSaver) GetEncoding() *SourceEncoding {
+	return wrapSourceEncoding(C.gtk_source_file_saver_get_encoding(v.native()))
+}
+
+// SetEncoding is a wrapper around gtk_source_file_saver_set_encoding().
+// A nil encoding selects UTF-8.
+func (v *SourceFileSaver) SetEncoding(encoding *SourceEncoding) {
+	C.gtk_source_file_saver_set_encoding(v.native(), encoding.native())
+}
+
+// GetNewlineType is a wrapper around gtk_source_file_saver_get_newline_type().
+func (v *SourceFileSaver) GetNewlineType() NewlineType {
+	return NewlineType(C.gtk_source_file_saver_get_newline_type(v.native()))
+}
+
+// SetNewlineType is a wrapper around gtk_source_file_saver_set_newline_type().
+func (v *SourceFileSaver) SetNewlineType(newlineType NewlineType) {
+	C.gtk_source_file_saver_set_newline_type(v.native(), C.GtkSourceNewlineType(newlineType))
+}
+
+// GetCompressionType is a wrapper around gtk_source_file_saver_get_compression_type().
+func (v *SourceFileSaver) GetCompressionType() CompressionType {
+	return CompressionType(C.gtk_source_file_saver_get_compression_type(v.native()))
+}
+
+// SetCompressionType is a wrapper around gtk_source_file_saver_set_compression_type().
+func (v *SourceFileSaver) SetCompressionType(compressionType CompressionType) {
+	C.gtk_source_file_saver_set_compression_type(v.native(), C.GtkSourceCompressionType(compressionType))
+}
+
+// GetFlags is a wrapper around gtk_source_file_saver_get_flags().
+func (v *SourceFileSaver) GetFlags() SourceFileSaverFlags {
+	return SourceFileSaverFlags(C.gtk_source_file_saver_get_flags(v.native()))
+}
+
+// SetFlags is a wrapper around gtk_source_file_saver_set_flags().
+func (v *SourceFileSaver) SetFlags(flags SourceFileSaverFlags) {
+	C.gtk_source_file_saver_set_flags(v.native(), C.GtkSourceFileSaverFlags(flags))
+}
+
+// SaveAsync is a wrapper around gtk_source_file_saver_save_async().
+// ioPriority is a GLib I/O priority, where 0 is G_PRIORITY_DEFAULT. progress
+// may be nil. callback runs on the GTK main loop once saving is done. For
+// the corresponding conflicts it receives an error carrying GTK's message
+// that matches ErrInvalidChars or ErrExternallyModified with errors.Is, and
+// it receives ctx.Err() if saving was cancelled because ctx is done.
+func (v *SourceFileSaver) SaveAsync(ctx context.Context, ioPriority int, progress FileProgressFunc, callback func(err error)) {
+	cancellable, release := cancellableNew(ctx)
+	data := registerAsyncReady(func(res *C.GAsyncResult) {
+		defer release()
+
+		var err *C.GError
+		C.gtk_source_file_saver_save_finish(v.native(), res, &err)
+		callback(fileSaverError(ctx, err))
+	})
+	C._gtk_source_file_saver_save_async(v.native(), C.gint(ioPriority), cancellable,
+		registerFileProgress(progress), data)
+}
+
+// fileSaverConflict is a GtkSourceFileSaverError that keeps the message
+// reported by GTK while matching its sentinel error with errors.Is.
+type fileSaverConflict struct {
+	msg      string
+	sentinel error
+}
+
+func (e *fileSaverConflict) Error() string {
+	return e.msg
+}
+
+func (e *fileSaverConflict) Unwrap() error {
+	return e.sentinel
+}
+
+// fileSaverError converts err, the error of an asynchronous save, to a Go
+// error, mapping GtkSourceFileSaverError codes to their Go counterparts.
+func fileSaverError(ctx context.Context, err *C.GError) error {
+	if err != nil && err.domain == C.gtk_source_file_saver_error_quark() {
+		var sentinel error
+		switch err.code {
+		case C.GTK_SOURCE_FILE_SAVER_ERROR_INVALID_CHARS:
+			sentinel = ErrInvalidChars
+		case C.GTK_SOURCE_FILE_SAVER_ERROR_EXTERNALLY_MODIFIED:
+			sentinel = ErrExternallyModified
+		}
+		if sentinel != nil {
+			return &fileSaverConflict{goError(err).Error(), sentinel}
+		}
+	}
+	return asyncError(ctx, err)
+}
@@ -0,0 +1,37 @@
+static GtkSourceFile *
+toGtkSourceFile(void *p)
+{
+	return (GTK_SOURCE_FILE(p));
+}
+
+static GtkSourceFileLoader *
+toGtkSourceFileLoader(void *p)
+{
+	return (GTK_SOURCE_FILE_LOADER(p));
+}
+
+static GtkSourceFileSaver *
+toGtkSourceFileSaver(void *p)
+{
+	return (GTK_SOURCE_FILE_SAVER(p));
+}
+
+static inline void
+_gtk_source_file_loader_load_async(GtkSourceFileLoader *loader, gint io_priority,
+	GCancellable *cancellable, gpointer progress_data, gpointer user_data)
+{
+	gtk_source_file_loader_load_async(loader, io_priority, cancellable,
+		progress_data != NULL ? (GFileProgressCallback)(goFileProgressCallback) : NULL,
+		progress_data, (GDestroyNotify)(goFileProgressDestroyNotify),
+		(GAsyncReadyCallback)(goAsyncReadyCallback), user_data);
+}
+
+static inline void
+_gtk_source_file_saver_save_async(GtkSourceFileSaver *saver, gint io_priority,
+	GCancellable *cancellable, gpointer progress_data, gpointer user_data)
+{
+	gtk_source_file_saver_save_async(saver, io_priority, cancellable,
+		progress_data != NULL ? (GFileProgressCallback)(goFileProgressCallback) : NULL,
+		progress_data, (GDestroyNotify)(goFileProgressDestroyNotify),
+		(GAsyncReadyCallback)(goAsyncReadyCallback), user_data);
+}
@@ -0,0 +1,454 @@
+package sourceview
+
+// #cgo pkg-config: gtksourceview-3.0
+// #include <gtksourceview/gtksourcegutterrenderer.h>
+// #include <gtksourceview/gtksourcegutterrendererpixbuf.h>
+// #include <gtksourceview/gtksourcegutterrenderertext.h>
+// #include <gtksourceview/gtksourceview-typebuiltins.h>
+// #include "sourceview.go.h"
+// #include "gutter.go.h"
+import "C"
+import (
+	"errors"
+	"sync"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/cairo"
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+func init() {
+	tm := []glib.TypeMarshaler{
+		// Enums
+		{glib.Type(C.gtk_source_gutter_renderer_alignment_mode_get_type()), marshalSourceGutterRendererAlignmentMode},
+		{glib.Type(C.gtk_source_gutter_renderer_state_get_type()), marshalSourceGutterRendererState},
+
+		// Objects/Interfaces
+		{glib.Type(C.gtk_source_gutter_renderer_get_type()), marshalSourceGutterRenderer},
+		{glib.Type(C.gtk_source_gutter_renderer_pixbuf_get_type()), marshalSourceGutterRendererPixbuf},
+		{glib.Type(C.gtk_source_gutter_renderer_text_get_type()), marshalSourceGutterRendererText},
+	}
+	glib.RegisterGValueMarshalers(tm)
+
+	gtk.WrapMap["GtkSourceGutterRenderer"] = wrapSourceGutterRenderer
+	gtk.WrapMap["GtkSourceGutterRendererPixbuf"] = wrapSourceGutterRendererPixbuf
+	gtk.WrapMap["GtkSourceGutterRendererText"] = wrapSourceGutterRendererText
+}
+
+/*
+ * Constants
+ */
+
+// SourceGutterRendererAlignmentMode is a representation of GtkSourceGutterRendererAlignmentMode.
+type SourceGutterRendererAlignmentMode int
+
+const (
+	SOURCE_GUTTER_RENDERER_ALIGNMENT_MODE_CELL  SourceGutterRendererAlignmentMode = C.GTK_SOURCE_GUTTER_RENDERER_ALIGNMENT_MODE_CELL
+	SOURCE_GUTTER_RENDERER_ALIGNMENT_MODE_FIRST SourceGutterRendererAlignmentMode = C.GTK_SOURCE_GUTTER_RENDERER_ALIGNMENT_MODE_FIRST
+	SOURCE_GUTTER_RENDERER_ALIGNMENT_MODE_LAST  SourceGutterRendererAlignmentMode = C.GTK_SOURCE_GUTTER_RENDERER_ALIGNMENT_MODE_LAST
+)
+
+func marshalSourceGutterRendererAlignmentMode(p uintptr) (interface{}, error) {
+	c := C.g_value_get_enum((*C.GValue)(unsafe.Pointer(p)))
+	return SourceGutterRendererAlignmentMode(c), nil
+}
+
+// SourceGutterRendererState is a representation of GtkSourceGutterRendererState.
+type SourceGutterRendererState int
+
+const (
+	SOURCE_GUTTER_RENDERER_STATE_NORMAL   SourceGutterRendererState = C.GTK_SOURCE_GUTTER_RENDERER_STATE_NORMAL
+	SOURCE_GUTTER_RENDERER_STATE_CURSOR   SourceGutterRendererState = C.GTK_SOURCE_GUTTER_RENDERER_STATE_CURSOR
+	SOURCE_GUTTER_RENDERER_STATE_PRELIT   SourceGutterRendererState = C.GTK_SOURCE_GUTTER_RENDERER_STATE_PRELIT
+	SOURCE_GUTTER_RENDERER_STATE_SELECTED SourceGutterRendererState = C.GTK_SOURCE_GUTTER_RENDERER_STATE_SELECTED
+)
+
+func marshalSourceGutterRendererState(p uintptr) (interface{}, error) {
+	c := C.g_value_get_flags((*C.GValue)(unsafe.Pointer(p)))
+	return SourceGutterRendererState(c), nil
+}
+
+/*
+ * GtkSourceGutterRenderer
+ */
+
+// ISourceGutterRenderer is an interface type implemented by all structs
+// embedding a SourceGutterRenderer. It is meant to be used as an argument
+// type for wrapper functions that wrap around a C function taking a
+// GtkSourceGutterRenderer.
+type ISourceGutterRenderer interface {
+	toSourceGutterRenderer() *C.GtkSourceGutterRenderer
+}
+
+// SourceGutterRenderer is a representation of GtkSourceGutterRenderer, the
+// abstract base class of all gutter renderers.
+type SourceGutterRenderer struct {
+	glib.InitiallyUnowned
+}
+
+// native returns a pointer to the underlying GtkSourceGutterRenderer.
+func (v *SourceGutterRenderer) native() *C.GtkSourceGutterRenderer {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceGutterRenderer(p)
+}
+
+func (v *SourceGutterRenderer) toSourceGutterRenderer() *C.GtkSourceGutterRenderer {
+	if v == nil {
+		return nil
+	}
+	return v.native()
+}
+
+func marshalSourceGutterRenderer(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceGutterRenderer(obj), nil
+}
+
+func wrapSourceGutterRenderer(obj *glib.Object) *SourceGutterRenderer {
+	return &SourceGutterRenderer{glib.InitiallyUnowned{obj}}
+}
+
+// GetAlignment is a wrapper around gtk_source_gutter_renderer_get_alignment().
+func (v *SourceGutterRenderer) GetAlignment() (xalign, yalign float32) {
+	var cxalign, cyalign C.gfloat
+	C.gtk_source_gutter_renderer_get_alignment(v.native(), &cxalign, &cyalign)
+	return float32(cxalign), float32(cyalign)
+}
+
+// SetAlignment is a wrapper around gtk_source_gutter_renderer_set_alignment().
+// A negative value leaves the corresponding alignment unchanged.
+func (v *SourceGutterRenderer) SetAlignment(xalign, yalign float32) {
+	C.gtk_source_gutter_renderer_set_alignment(v.native(), C.gfloat(xalign), C.gfloat(yalign))
+}
+
+// GetAlignmentMode is a wrapper around gtk_source_gutter_renderer_get_alignment_mode().
+func (v *SourceGutterRenderer) GetAlignmentMode() SourceGutterRendererAlignmentMode {
+	return SourceGutterRendererAlignmentMode(C.gtk_source_gutter_renderer_get_alignment_mode(v.native()))
+}
+
+// SetAlignmentMode is a wrapper around gtk_source_gutter_renderer_set_alignment_mode().
+func (v *SourceGutterRenderer) SetAlignmentMode(mode SourceGutterRendererAlignmentMode) {
+	C.gtk_source_gutter_renderer_set_alignment_mode(v.native(), C.GtkSourceGutterRendererAlignmentMode(mode))
+}
+
+// GetPadding is a wrapper around gtk_source_gutter_renderer_get_padding().
+func (v *SourceGutterRenderer) GetPadding() (xpad, ypad int) {
+	var cxpad, cypad C.gint
+	C.gtk_source_gutter_renderer_get_padding(v.native(), &cxpad, &cypad)
+	return int(cxpad), int(cypad)
+}
+
+// SetPadding is a wrapper around gtk_source_gutter_renderer_set_padding().
+// A negative value leaves the corresponding padding unchanged.
+func (v *SourceGutterRenderer) SetPadding(xpad, ypad int) {
+	C.gtk_source_gutter_renderer_set_padding(v.native(), C.gint(xpad), C.gint(ypad))
+}
+
+// GetSize is a wrapper around gtk_source_gutter_renderer_get_size().
+func (v *SourceGutterRenderer) GetSize() int {
+	return int(C.gtk_source_gutter_renderer_get_size(v.native()))
+}
+
+// SetSize is a wrapper around gtk_source_gutter_renderer_set_size().
+func (v *SourceGutterRenderer) SetSize(size int) {
+	C.gtk_source_gutter_renderer_set_size(v.native(), C.gint(size))
+}
+
+// GetVisible is a wrapper around gtk_source_gutter_renderer_get_visible().
+func (v *SourceGutterRenderer) GetVisible() bool {
+	return gobool(C.gtk_source_gutter_renderer_get_visible(v.native()))
+}
+
+// SetVisible is a wrapper around gtk_source_gutter_renderer_set_visible().
+func (v *SourceGutterRenderer) SetVisible(visible bool) {
+	C.gtk_source_gutter_renderer_set_visible(v.native(), gbool(visible))
+}
+
+// GetBackground is a wrapper around gtk_source_gutter_renderer_get_background().
+// The returned bool is false if no background color is set.
+func (v *SourceGutterRenderer) GetBackground() (*gdk.RGBA, bool) {
+	var color C.GdkRGBA
+	c := C.gtk_source_gutter_renderer_get_background(v.native(), &color)
+	if !gobool(c) {
+		return nil, false
+	}
+	return gdk.WrapRGBA(unsafe.Pointer(&color)), true
+}
+
+// SetBackground is a wrapper around gtk_source_gutter_renderer_set_background().
+// A nil color unsets the background.
+func (v *SourceGutterRenderer) SetBackground(color *gdk.RGBA) {
+	var ccolor *C.GdkRGBA
+	if color != nil {
+		ccolor = (*C.GdkRGBA)(unsafe.Pointer(color.Native()))
+	}
+	C.gtk_source_gutter_renderer_set_background(v.native(), ccolor)
+}
+
+// GetWindowType is a wrapper around gtk_source_gutter_renderer_get_window_type().
+// It returns gtk.TEXT_WINDOW_PRIVATE while the renderer is not in a gutter.
+func (v *SourceGutterRenderer) GetWindowType() gtk.TextWindowType {
+	return gtk.TextWindowType(C.gtk_source_gutter_renderer_get_window_type(v.native()))
+}
+
+// GetView is a wrapper around gtk_source_gutter_renderer_get_view().
+func (v *SourceGutterRenderer) GetView() (*gtk.TextView, error) {
+	c := C.gtk_source_gutter_renderer_get_view(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	obj := glib.Take(unsafe.Pointer(c))
+	return &gtk.TextView{gtk.Container{gtk.Widget{glib.InitiallyUnowned{obj}}}}, nil
+}
+
+// QueueDraw is a wrapper around gtk_source_gutter_renderer_queue_draw().
+func (v *SourceGutterRenderer) QueueDraw() {
+	C.gtk_source_gutter_renderer_queue_draw(v.native())
+}
+
+// ConnectQueryData connects f to the "query-data" signal, emitted before
+// drawing the line from start to end so that f can update the data to
+// render, for example with SourceGutterRendererText.SetText. start and end
+// are only valid during the call to f.
+func (v *SourceGutterRenderer) ConnectQueryData(f func(start, end *gtk.TextIter, state SourceGutterRendererState)) (glib.SignalHandle, error) {
+	// The emitting instance is marshaled to its most derived wrapper type,
+	// which differs between renderer classes.
+	return v.Connect("query-data", func(_ interface{}, start, end *gtk.TextIter, state SourceGutterRendererState) {
+		f(start, end, state)
+	})
+}
+
+// GutterRenderer is the interface implemented by Go gutter renderers. Its
+// methods are the virtual functions of GtkSourceGutterRendererClass; use
+// SourceGutterRendererNew to turn an implementation into a
+// GtkSourceGutterRenderer that can be inserted in a SourceGutter.
+//
+// The iterators, rectangles, cairo context and event passed to the methods
+// are only valid for the duration of the call.
+type GutterRenderer interface {
+	// Begin is called once before a series of Draw calls, with the area
+	// and lines about to be drawn.
+	Begin(cr *cairo.Context, backgroundArea, cellArea *gdk.Rectangle, start, end *gtk.TextIter)
+
+	// Draw draws the cell of the line from start to end. The background
+	// color of the renderer, if any, has already been drawn.
+	Draw(cr *cairo.Context, backgroundArea, cellArea *gdk.Rectangle, start, end *gtk.TextIter, state SourceGutterRendererState)
+
+	// End is called once after a series of Draw calls.
+	End()
+
+	// QueryData is called before Draw to update the data to be drawn for
+	// the line from start to end.
+	QueryData(start, end *gtk.TextIter, state SourceGutterRendererState)
+
+	// QueryActivatable reports whether the renderer can be activated at
+	// iter by event.
+	QueryActivatable(iter *gtk.TextIter, area *gdk.Rectangle, event *gdk.Event) bool
+
+	// Activate is called when the renderer is activated at iter.
+	Activate(iter *gtk.TextIter, area *gdk.Rectangle, event *gdk.Event)
+}
+
+var errNilGutterRenderer = errors.New("gutter renderer is nil")
+
+var gutterRendererRegistry = struct {
+	sync.RWMutex
+	next int
+	m    map[int]GutterRenderer
+}{
+	next: 1,
+	m:    make(map[int]GutterRenderer),
+}
+
+// SourceGutterRendererNew creates a GtkSourceGutterRenderer whose virtual
+// functions are implemented by renderer. renderer is released when the
+// returned object is finalized.
+func SourceGutterRendererNew(renderer GutterRenderer) (*SourceGutterRenderer, error) {
+	if renderer == nil {
+		return nil, errNilGutterRenderer
+	}
+
+	gutterRendererRegistry.Lock()
+	id := gutterRendererRegistry.next
+	gutterRendererRegistry.next++
+	gutterRendererRegistry.m[id] = renderer
+	gutterRendererRegistry.Unlock()
+
+	c := C.go_source_gutter_renderer_new(C.gint(id))
+	if c == nil {
+		gutterRendererRegistry.Lock()
+		delete(gutterRendererRegistry.m, id)
+		gutterRendererRegistry.Unlock()
+		return nil, errNilPtr
+	}
+	return wrapSourceGutterRenderer(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// lookupGutterRenderer returns the Go renderer registered under handle.
+func lookupGutterRenderer(handle C.gint) GutterRenderer {
+	gutterRendererRegistry.RLock()
+	defer gutterRendererRegistry.RUnlock()
+	return gutterRendererRegistry.m[int(handle)]
+}
+
+/*
+ * GtkSourceGutterRendererText
+ */
+
+// SourceGutterRendererText is a representation of GtkSourceGutterRendererText.
+type SourceGutterRendererText struct {
+	SourceGutterRenderer
+}
+
+// native returns a pointer to the underlying GtkSourceGutterRendererText.
+func (v *SourceGutterRendererText) native() *C.GtkSourceGutterRendererText {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceGutterRendererText(p)
+}
+
+func marshalSourceGutterRendererText(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceGutterRendererText(obj), nil
+}
+
+func wrapSourceGutterRendererText(obj *glib.Object) *SourceGutterRendererText {
+	return &SourceGutterRendererText{SourceGutterRenderer{glib.InitiallyUnowned{obj}}}
+}
+
+// SourceGutterRendererTextNew is a wrapper around gtk_source_gutter_renderer_text_new().
+func SourceGutterRendererTextNew() (*SourceGutterRendererText, error) {
+	c := C.gtk_source_gutter_renderer_text_new()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceGutterRendererText(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// SetText is a wrapper around gtk_source_gutter_renderer_text_set_text().
+func (v *SourceGutterRendererText) SetText(text string) {
+	cstr := C.CString(text)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_gutter_renderer_text_set_text(v.native(), (*C.gchar)(cstr), C.gint(len(text)))
+}
+
+// SetMarkup is a wrapper around gtk_source_gutter_renderer_text_set_markup().
+func (v *SourceGutterRendererText) SetMarkup(markup string) {
+	cstr := C.CString(markup)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_gutter_renderer_text_set_markup(v.native(), (*C.gchar)(cstr), C.gint(len(markup)))
+}
+
+// Measure is a wrapper around gtk_source_gutter_renderer_text_measure().
+// It returns the size in pixels text would take when rendered.
+func (v *SourceGutterRendererText) Measure(text string) (width, height int) {
+	cstr := C.CString(text)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var cwidth, cheight C.gint
+	C.gtk_source_gutter_renderer_text_measure(v.native(), (*C.gchar)(cstr), &cwidth, &cheight)
+	return int(cwidth), int(cheight)
+}
+
+// MeasureMarkup is a wrapper around gtk_source_gutter_renderer_text_measure_markup().
+// It returns the size in pixels markup would take when rendered.
+func (v *SourceGutterRendererText) MeasureMarkup(markup string) (width, height int) {
+	cstr := C.CString(markup)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var cwidth, cheight C.gint
+	C.gtk_source_gutter_renderer_text_measure_markup(v.native(), (*C.gchar)(cstr), &cwidth, &cheight)
+	return int(cwidth), int(cheight)
+}
+
+/*
+ * GtkSourceGutterRendererPixbuf
+ */
+
+// SourceGutterRendererPixbuf is a representation of GtkSourceGutterRendererPixbuf.
+type SourceGutterRendererPixbuf struct {
+	SourceGutterRenderer
+}
+
+// native returns a pointer to the underlying GtkSourceGutterRendererPixbuf.
+func (v *SourceGutterRendererPixbuf) native() *C.GtkSourceGutterRendererPixbuf {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceGutterRendererPixbuf(p)
+}
+
+func marshalSourceGutterRendererPixbuf(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceGutterRendererPixbuf(obj), nil
+}
+
+func wrapSourceGutterRendererPixbuf(obj *glib.Object) *SourceGutterRendererPixbuf {
+	return &SourceGutterRendererPixbuf{SourceGutterRenderer{glib.InitiallyUnowned{obj}}}
+}
+
+// SourceGutterRendererPixbufNew is a wrapper around gtk_source_gutter_renderer_pixbuf_new().
+func SourceGutterRendererPixbufNew() (*SourceGutterRendererPixbuf, error) {
+	c := C.gtk_source_gutter_renderer_pixbuf_new()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceGutterRendererPixbuf(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetPixbuf is a wrapper around gtk_source_gutter_renderer_pixbuf_get_pixbuf().
+func (v *SourceGutterRendererPixbuf) GetPixbuf() *gdk.Pixbuf {
+	c := C.gtk_source_gutter_renderer_pixbuf_get_pixbuf(v.native())
+	if c == nil {
+		return nil
+	}
+	return &gdk.Pixbuf{glib.Take(unsafe.Pointer(c))}
+}
+
+// SetPixbuf is a wrapper around gtk_source_gutter_renderer_pixbuf_set_pixbuf().
+func (v *SourceGutterRendererPixbuf) SetPixbuf(pixbuf *gdk.Pixbuf) {
+	C.gtk_source_gutter_renderer_pixbuf_set_pixbuf(v.native(), nativePixbuf(pixbuf))
+}
+
+// GetGIcon is a wrapper around gtk_source_gutter_renderer_pixbuf_get_gicon().
+func (v *SourceGutterRendererPixbuf) GetGIcon() *glib.Object {
+	c := C.gtk_source_gutter_renderer_pixbuf_get_gicon(v.native())
+	if c == nil {
+		return nil
+	}
+	return glib.Take(unsafe.Pointer(c))
+}
+
+// SetGIcon is a wrapper around gtk_source_gutter_renderer_pixbuf_set_gicon().
+// gicon must implement the GIcon interface, or be nil.
+func (v *SourceGutterRendererPixbuf) SetGIcon(gicon *glib.Object) {
+	C.gtk_source_gutter_renderer_pixbuf_set_gicon(v.native(), nativeGIcon(gicon))
+}
+
+// GetIconName is a wrapper around gtk_source_gutter_renderer_pixbuf_get_icon_name().
+func (v *SourceGutterRendererPixbuf) GetIconName() string {
+	c := C.gtk_source_gutter_renderer_pixbuf_get_icon_name(v.native())
+	if c == nil {
+		return ""
+	}
+	return goString(c)
+}
+
+// SetIconName is a wrapper around gtk_source_gutter_renderer_pixbuf_set_icon_name().
+// An empty name unsets the icon.
+func (v *SourceGutterRendererPixbuf) SetIconName(iconName string) {
+	cstr := cstringOrNil(iconName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_gutter_renderer_pixbuf_set_icon_name(v.native(), cstr)
+}
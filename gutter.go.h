@@ -0,0 +1,140 @@
+static GtkSourceGutterRenderer *
+toGtkSourceGutterRenderer(void *p)
+{
+	return (GTK_SOURCE_GUTTER_RENDERER(p));
+}
+
+static GtkSourceGutterRendererPixbuf *
+toGtkSourceGutterRendererPixbuf(void *p)
+{
+	return (GTK_SOURCE_GUTTER_RENDERER_PIXBUF(p));
+}
+
+static GtkSourceGutterRendererText *
+toGtkSourceGutterRendererText(void *p)
+{
+	return (GTK_SOURCE_GUTTER_RENDERER_TEXT(p));
+}
+
+/*
+ * GoSourceGutterRenderer is a GtkSourceGutterRenderer subclass forwarding its
+ * virtual functions to the Go GutterRenderer registered under its handle.
+ */
+
+extern void goGutterRendererBegin(gint handle, cairo_t *cr,
+	GdkRectangle *background_area, GdkRectangle *cell_area,
+	GtkTextIter *start, GtkTextIter *end);
+extern void goGutterRendererDraw(gint handle, cairo_t *cr,
+	GdkRectangle *background_area, GdkRectangle *cell_area,
+	GtkTextIter *start, GtkTextIter *end, GtkSourceGutterRendererState state);
+extern void goGutterRendererEnd(gint handle);
+extern void goGutterRendererQueryData(gint handle, GtkTextIter *start,
+	GtkTextIter *end, GtkSourceGutterRendererState state);
+extern gboolean goGutterRendererQueryActivatable(gint handle,
+	GtkTextIter *iter, GdkRectangle *area, GdkEvent *event);
+extern void goGutterRendererActivate(gint handle, GtkTextIter *iter,
+	GdkRectangle *area, GdkEvent *event);
+extern void goGutterRendererFinalize(gint handle);
+
+typedef struct {
+	GtkSourceGutterRenderer parent_instance;
+	gint handle;
+} GoSourceGutterRenderer;
+
+typedef struct {
+	GtkSourceGutterRendererClass parent_class;
+} GoSourceGutterRendererClass;
+
+G_DEFINE_TYPE(GoSourceGutterRenderer, go_source_gutter_renderer, GTK_SOURCE_TYPE_GUTTER_RENDERER)
+
+#define GO_SOURCE_GUTTER_RENDERER_HANDLE(p) (((GoSourceGutterRenderer *)(p))->handle)
+
+static void
+go_source_gutter_renderer_begin(GtkSourceGutterRenderer *renderer, cairo_t *cr,
+	GdkRectangle *background_area, GdkRectangle *cell_area,
+	GtkTextIter *start, GtkTextIter *end)
+{
+	goGutterRendererBegin(GO_SOURCE_GUTTER_RENDERER_HANDLE(renderer), cr,
+		background_area, cell_area, start, end);
+}
+
+static void
+go_source_gutter_renderer_draw(GtkSourceGutterRenderer *renderer, cairo_t *cr,
+	GdkRectangle *background_area, GdkRectangle *cell_area,
+	GtkTextIter *start, GtkTextIter *end, GtkSourceGutterRendererState state)
+{
+	/* Chain up to draw the background. */
+	GTK_SOURCE_GUTTER_RENDERER_CLASS(go_source_gutter_renderer_parent_class)->draw(renderer,
+		cr, background_area, cell_area, start, end, state);
+
+	goGutterRendererDraw(GO_SOURCE_GUTTER_RENDERER_HANDLE(renderer), cr,
+		background_area, cell_area, start, end, state);
+}
+
+static void
+go_source_gutter_renderer_end(GtkSourceGutterRenderer *renderer)
+{
+	goGutterRendererEnd(GO_SOURCE_GUTTER_RENDERER_HANDLE(renderer));
+}
+
+static void
+go_source_gutter_renderer_query_data(GtkSourceGutterRenderer *renderer,
+	GtkTextIter *start, GtkTextIter *end, GtkSourceGutterRendererState state)
+{
+	goGutterRendererQueryData(GO_SOURCE_GUTTER_RENDERER_HANDLE(renderer),
+		start, end, state);
+}
+
+static gboolean
+go_source_gutter_renderer_query_activatable(GtkSourceGutterRenderer *renderer,
+	GtkTextIter *iter, GdkRectangle *area, GdkEvent *event)
+{
+	return goGutterRendererQueryActivatable(GO_SOURCE_GUTTER_RENDERER_HANDLE(renderer),
+		iter, area, event);
+}
+
+static void
+go_source_gutter_renderer_activate(GtkSourceGutterRenderer *renderer,
+	GtkTextIter *iter, GdkRectangle *area, GdkEvent *event)
+{
+	goGutterRendererActivate(GO_SOURCE_GUTTER_RENDERER_HANDLE(renderer),
+		iter, area, event);
+}
+
+static void
+go_source_gutter_renderer_finalize(GObject *object)
+{
+	goGutterRendererFinalize(GO_SOURCE_GUTTER_RENDERER_HANDLE(object));
+	G_OBJECT_CLASS(go_source_gutter_renderer_parent_class)->finalize(object);
+}
+
+static void
+go_source_gutter_renderer_class_init(GoSourceGutterRendererClass *klass)
+{
+	GObjectClass *object_class = G_OBJECT_CLASS(klass);
+	GtkSourceGutterRendererClass *renderer_class = GTK_SOURCE_GUTTER_RENDERER_CLASS(klass);
+
+	object_class->finalize = go_source_gutter_renderer_finalize;
+
+	renderer_class->begin = go_source_gutter_renderer_begin;
+	renderer_class->draw = go_source_gutter_renderer_draw;
+	renderer_class->end = go_source_gutter_renderer_end;
+	renderer_class->query_data = go_source_gutter_renderer_query_data;
+	renderer_class->query_activatable = go_source_gutter_renderer_query_activatable;
+	renderer_class->activate = go_source_gutter_renderer_activate;
+}
+
+static void
+go_source_gutter_renderer_init(GoSourceGutterRenderer *self)
+{
+}
+
+static GtkSourceGutterRenderer *
+go_source_gutter_renderer_new(gint handle)
+{
+	GoSourceGutterRenderer *renderer;
+
+	renderer = g_object_new(go_source_gutter_renderer_get_type(), NULL);
+	renderer->handle = handle;
+	return GTK_SOURCE_GUTTER_RENDERER(renderer);
+}
@@ -0,0 +1,262 @@
+// Package langspec parses and validates GtkSourceView language definition
+// (.lang) files without loading GTK.
+//
+// Parse reads a file into a Language, checking it against the structure of
+// the language2.rng schema and the references that can be checked within a
+// single file. A Resolver then checks references to other languages, such
+// as "def:comment", by loading them from a search path, either the one of a
+// SourceLanguageManager or DefaultSearchPath.
+//
+// All errors are reported as an ErrorList of file and line positioned
+// errors.
+package langspec
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Language is a language definition, the <language> root element.
+type Language struct {
+	// Filename is the name the language was parsed from, used in errors.
+	Filename string
+	Line     int
+
+	ID                string
+	Name              string
+	Section           string
+	Version           string
+	TranslationDomain string
+	Hidden            bool
+
+	// Metadata holds the <property> elements of <metadata>, such as
+	// "mimetypes", "globs" and "line-comment-start".
+	Metadata map[string]string
+
+	Styles              []*Style
+	DefaultRegexOptions RegexOptions
+	KeywordCharClass    string
+
+	// Contexts, Regexes and Replaces hold the content of <definitions>.
+	Contexts []*Context
+	Regexes  []*DefineRegex
+	Replaces []*Replace
+}
+
+// Style returns the style with the given id, or nil.
+func (l *Language) Style(id string) *Style {
+	for _, s := range l.Styles {
+		if s.ID == id {
+			return s
+		}
+	}
+	return nil
+}
+
+// Regex returns the regular expression defined with the given id, or nil.
+func (l *Language) Regex(id string) *DefineRegex {
+	for _, r := range l.Regexes {
+		if r.ID == id {
+			return r
+		}
+	}
+	return nil
+}
+
+// Context returns the context with the given id, searching nested contexts
+// as well as top-level ones, or nil.
+func (l *Language) Context(id string) *Context {
+	var found *Context
+	l.Walk(func(c *Context) bool {
+		if c.ID == id {
+			found = c
+		}
+		return found == nil
+	})
+	return found
+}
+
+// Walk calls f for every context of the language in depth-first order,
+// stopping as soon as f returns false.
+func (l *Language) Walk(f func(c *Context) bool) {
+	for _, c := range l.Contexts {
+		if !c.walk(f) {
+			return
+		}
+	}
+}
+
+// Style is a <style> element of <styles>.
+type Style struct {
+	Line  int
+	ID    string
+	Name  string
+	MapTo string
+}
+
+// RegexOptions holds the regex option attributes of an element. A nil
+// option is inherited from the enclosing definition.
+type RegexOptions struct {
+	CaseSensitive *bool
+	Extended      *bool
+	DupNames      *bool
+}
+
+// Regex is a regular expression, such as the content of <match>, <start>
+// or <keyword>. Pattern is in PCRE syntax extended with GtkSourceView's
+// \%{id} references and \%[ and \%] word boundaries.
+type Regex struct {
+	Line    int
+	Pattern string
+	Options RegexOptions
+}
+
+// DefineRegex is a <define-regex> element.
+type DefineRegex struct {
+	ID string
+	Regex
+}
+
+// Replace is a <replace> element, substituting the context Ref for the
+// context ID.
+type Replace struct {
+	Line int
+	ID   string
+	Ref  string
+}
+
+// ContextKind is the kind of a <context> element, determined by its
+// attributes and children.
+type ContextKind int
+
+const (
+	// SimpleContext matches a single regex, given in Match.
+	SimpleContext ContextKind = iota
+	// ContainerContext spans from Start to End and may include other
+	// contexts.
+	ContainerContext
+	// KeywordContext matches any of Keywords.
+	KeywordContext
+	// ReferenceContext includes the context named by Ref.
+	ReferenceContext
+	// SubPatternContext styles the named group SubPattern of the regex of
+	// its parent.
+	SubPatternContext
+)
+
+var contextKindNames = [...]string{
+	SimpleContext:     "simple",
+	ContainerContext:  "container",
+	KeywordContext:    "keyword",
+	ReferenceContext:  "reference",
+	SubPatternContext: "sub-pattern",
+}
+
+func (k ContextKind) String() string {
+	if k < 0 || int(k) >= len(contextKindNames) {
+		return fmt.Sprintf("ContextKind(%d)", int(k))
+	}
+	return contextKindNames[k]
+}
+
+// Context is a <context> element. Which fields are set depends on Kind.
+type Context struct {
+	Line int
+	Kind ContextKind
+
+	ID            string
+	StyleRef      string
+	Class         []string
+	ClassDisabled []string
+
+	ExtendParent  bool
+	EndParent     bool
+	EndAtLineEnd  bool
+	FirstLineOnly bool
+	OnceOnly      bool
+	StyleInside   bool
+
+	// Match is set for simple contexts, Start and End for containers.
+	Match *Regex
+	Start *Regex
+	End   *Regex
+
+	// Prefix, Suffix and Keywords are set for keyword contexts.
+	Prefix   *Regex
+	Suffix   *Regex
+	Keywords []*Regex
+
+	// Include holds the contexts of <include>.
+	Include []*Context
+
+	// Ref, IgnoreStyle and Original are set for reference contexts.
+	Ref         string
+	IgnoreStyle bool
+	Original    bool
+
+	// SubPattern and Where are set for sub-pattern contexts. Where is
+	// empty, "start" or "end".
+	SubPattern string
+	Where      string
+}
+
+func (c *Context) walk(f func(c *Context) bool) bool {
+	if !f(c) {
+		return false
+	}
+	for _, child := range c.Include {
+		if !child.walk(f) {
+			return false
+		}
+	}
+	return true
+}
+
+// Error is an error at a line of a language definition file.
+type Error struct {
+	Filename string
+	Line     int
+	Msg      string
+}
+
+func (e *Error) Error() string {
+	if e.Filename == "" {
+		return fmt.Sprintf("%d: %s", e.Line, e.Msg)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.Filename, e.Line, e.Msg)
+}
+
+// ErrorList is a list of errors, in file and line order once sorted.
+type ErrorList []*Error
+
+func (l ErrorList) Error() string {
+	switch len(l) {
+	case 0:
+		return "no errors"
+	case 1:
+		return l[0].Error()
+	}
+	return fmt.Sprintf("%s (and %d more errors)", l[0], len(l)-1)
+}
+
+// Sort sorts the list by file name and line.
+func (l ErrorList) Sort() {
+	sort.SliceStable(l, func(i, j int) bool {
+		if l[i].Filename != l[j].Filename {
+			return l[i].Filename < l[j].Filename
+		}
+		return l[i].Line < l[j].Line
+	})
+}
+
+// Err returns l as an error, or nil if l is empty.
+func (l ErrorList) Err() error {
+	if len(l) == 0 {
+		return nil
+	}
+	return l
+}
+
+func (l *ErrorList) add(filename string, line int, format string, args ...interface{}) {
+	*l = append(*l, &Error{filename, line, fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,661 @@
+package langspec
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// node is an element of the XML document, with the line it starts at.
+type node struct {
+	name     string
+	attrs    []xml.Attr
+	children []*node
+	text     string
+	line     int
+}
+
+// readTree reads the XML document from r into a tree of nodes.
+func readTree(r io.Reader) (*node, error) {
+	d := xml.NewDecoder(r)
+
+	var root *node
+	var stack []*node
+	var text []*strings.Builder
+	for {
+		// The position before a start element is that of its '<', since
+		// the whitespace preceding it is a token of its own.
+		line, _ := d.InputPos()
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: tok.Name.Local, attrs: tok.Attr, line: line}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+			text = append(text, new(strings.Builder))
+		case xml.EndElement:
+			n := stack[len(stack)-1]
+			n.text = text[len(text)-1].String()
+			stack = stack[:len(stack)-1]
+			text = text[:len(text)-1]
+		case xml.CharData:
+			if len(text) > 0 {
+				text[len(text)-1].Write(tok)
+			}
+		}
+	}
+	if root == nil {
+		return nil, errors.New("no root element")
+	}
+	return root, nil
+}
+
+// ParseFile parses the language definition in the named file.
+func ParseFile(filename string) (*Language, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(filename, f)
+}
+
+// Parse parses a language definition from r. filename is only used in
+// errors.
+//
+// Parse checks the document against the language2.rng schema and checks
+// the references to styles, contexts and regexes of the language itself.
+// References to other languages are checked by Resolver.Resolve.
+//
+// If the document is well-formed XML, Parse returns a Language even when
+// it is invalid, along with an ErrorList.
+func Parse(filename string, r io.Reader) (*Language, error) {
+	root, err := readTree(r)
+	if err != nil {
+		var syntaxErr *xml.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return nil, ErrorList{{filename, syntaxErr.Line, syntaxErr.Msg}}
+		}
+		return nil, ErrorList{{filename, 0, err.Error()}}
+	}
+
+	p := &parser{filename: filename}
+	lang := p.language(root)
+	if lang != nil {
+		p.checkLocal(lang)
+	}
+	p.errs.Sort()
+	return lang, p.errs.Err()
+}
+
+type parser struct {
+	filename string
+	errs     ErrorList
+}
+
+func (p *parser) errorf(n *node, format string, args ...interface{}) {
+	p.errs.add(p.filename, n.line, format, args...)
+}
+
+// attrs returns the attributes of n by name, reporting any not in allowed.
+func (p *parser) attrs(n *node, allowed ...string) map[string]string {
+	m := make(map[string]string, len(n.attrs))
+	for _, a := range n.attrs {
+		if a.Name.Space == "xmlns" || (a.Name.Space == "" && a.Name.Local == "xmlns") {
+			continue
+		}
+		if !contains(allowed, a.Name.Local) {
+			p.errorf(n, "unexpected attribute %q on <%s>", a.Name.Local, n.name)
+			continue
+		}
+		m[a.Name.Local] = a.Value
+	}
+	return m
+}
+
+// boolAttr returns the boolean attribute name, or def if it is not set.
+func (p *parser) boolAttr(n *node, attrs map[string]string, name string, def bool) bool {
+	if b := p.optBoolAttr(n, attrs, name); b != nil {
+		return *b
+	}
+	return def
+}
+
+// optBoolAttr returns the boolean attribute name, or nil if it is not set.
+func (p *parser) optBoolAttr(n *node, attrs map[string]string, name string) *bool {
+	v, ok := attrs[name]
+	if !ok {
+		return nil
+	}
+	var b bool
+	switch v {
+	case "true":
+		b = true
+	case "false":
+	default:
+		p.errorf(n, "attribute %q of <%s> must be \"true\" or \"false\", not %q", name, n.name, v)
+		return nil
+	}
+	return &b
+}
+
+// requireAttr reports an error if none of names is set, and returns the
+// value of the first one that is.
+func (p *parser) requireAttr(n *node, attrs map[string]string, names ...string) string {
+	for _, name := range names {
+		if v, ok := attrs[name]; ok {
+			return v
+		}
+	}
+	p.errorf(n, "<%s> is missing the %q attribute", n.name, names[0])
+	return ""
+}
+
+// noText reports non-whitespace text directly inside n.
+func (p *parser) noText(n *node) {
+	if strings.TrimSpace(n.text) != "" {
+		p.errorf(n, "unexpected text in <%s>", n.name)
+	}
+}
+
+// leaf reports children of an element that may only contain text.
+func (p *parser) leaf(n *node) {
+	for _, child := range n.children {
+		p.errorf(child, "unexpected <%s> in <%s>", child.name, n.name)
+	}
+}
+
+// once reports the second and later occurrences of the same child.
+func (p *parser) once(seen map[string]bool, n *node) bool {
+	if seen[n.name] {
+		p.errorf(n, "duplicate <%s>", n.name)
+		return false
+	}
+	seen[n.name] = true
+	return true
+}
+
+var idPattern = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+func (p *parser) language(n *node) *Language {
+	if n.name != "language" {
+		p.errorf(n, "root element is <%s>, want <language>", n.name)
+		return nil
+	}
+	a := p.attrs(n, "id", "name", "_name", "section", "_section", "version",
+		"hidden", "translation-domain")
+	lang := &Language{
+		Filename:          p.filename,
+		Line:              n.line,
+		ID:                p.requireAttr(n, a, "id"),
+		Name:              p.requireAttr(n, a, "name", "_name"),
+		Section:           a["section"],
+		Version:           p.requireAttr(n, a, "version"),
+		TranslationDomain: a["translation-domain"],
+		Hidden:            p.boolAttr(n, a, "hidden", false),
+		Metadata:          make(map[string]string),
+	}
+	if s, ok := a["_section"]; ok {
+		lang.Section = s
+	}
+	if lang.ID != "" && !idPattern.MatchString(lang.ID) {
+		p.errorf(n, "invalid language id %q", lang.ID)
+	}
+	if lang.Version != "" && lang.Version != "2.0" {
+		p.errorf(n, "unsupported language version %q, want \"2.0\"", lang.Version)
+	}
+	p.noText(n)
+
+	seen := make(map[string]bool)
+	for _, child := range n.children {
+		switch child.name {
+		case "metadata":
+			if p.once(seen, child) {
+				p.metadata(child, lang)
+			}
+		case "styles":
+			if p.once(seen, child) {
+				p.styles(child, lang)
+			}
+		case "default-regex-options":
+			if p.once(seen, child) {
+				lang.DefaultRegexOptions = p.regexOptions(child, p.attrs(child,
+					"case-sensitive", "extended", "dupnames"))
+				p.noText(child)
+				p.leaf(child)
+			}
+		case "keyword-char-class":
+			if p.once(seen, child) {
+				lang.KeywordCharClass = strings.TrimSpace(child.text)
+				p.attrs(child)
+				p.leaf(child)
+			}
+		case "definitions":
+			if p.once(seen, child) {
+				p.definitions(child, lang)
+			}
+		default:
+			p.errorf(child, "unexpected <%s> in <language>", child.name)
+		}
+	}
+	if !seen["definitions"] {
+		p.errorf(n, "<language> is missing <definitions>")
+	}
+	return lang
+}
+
+func (p *parser) metadata(n *node, lang *Language) {
+	p.attrs(n)
+	p.noText(n)
+	for _, child := range n.children {
+		if child.name != "property" {
+			p.errorf(child, "unexpected <%s> in <metadata>", child.name)
+			continue
+		}
+		name := p.requireAttr(child, p.attrs(child, "name"), "name")
+		p.leaf(child)
+		if _, ok := lang.Metadata[name]; ok {
+			p.errorf(child, "duplicate property %q", name)
+		}
+		lang.Metadata[name] = strings.TrimSpace(child.text)
+	}
+}
+
+func (p *parser) styles(n *node, lang *Language) {
+	p.attrs(n)
+	p.noText(n)
+	for _, child := range n.children {
+		if child.name != "style" {
+			p.errorf(child, "unexpected <%s> in <styles>", child.name)
+			continue
+		}
+		a := p.attrs(child, "id", "name", "_name", "map-to")
+		style := &Style{
+			Line:  child.line,
+			ID:    p.requireAttr(child, a, "id"),
+			Name:  p.requireAttr(child, a, "name", "_name"),
+			MapTo: a["map-to"],
+		}
+		p.noText(child)
+		p.leaf(child)
+		lang.Styles = append(lang.Styles, style)
+	}
+}
+
+func (p *parser) definitions(n *node, lang *Language) {
+	p.attrs(n)
+	p.noText(n)
+	for _, child := range n.children {
+		switch child.name {
+		case "context":
+			if c := p.context(child, nil); c != nil {
+				lang.Contexts = append(lang.Contexts, c)
+			}
+		case "define-regex":
+			a := p.attrs(child, "id", "case-sensitive", "extended", "dupnames")
+			lang.Regexes = append(lang.Regexes, &DefineRegex{
+				ID:    p.requireAttr(child, a, "id"),
+				Regex: *p.regex(child, a),
+			})
+		case "replace":
+			a := p.attrs(child, "id", "ref")
+			lang.Replaces = append(lang.Replaces, &Replace{
+				Line: child.line,
+				ID:   p.requireAttr(child, a, "id"),
+				Ref:  p.requireAttr(child, a, "ref"),
+			})
+			p.noText(child)
+			p.leaf(child)
+		default:
+			p.errorf(child, "unexpected <%s> in <definitions>", child.name)
+		}
+	}
+}
+
+func (p *parser) regexOptions(n *node, a map[string]string) RegexOptions {
+	return RegexOptions{
+		CaseSensitive: p.optBoolAttr(n, a, "case-sensitive"),
+		Extended:      p.optBoolAttr(n, a, "extended"),
+		DupNames:      p.optBoolAttr(n, a, "dupnames"),
+	}
+}
+
+// regex parses a regex element whose attributes a have already been read.
+func (p *parser) regex(n *node, a map[string]string) *Regex {
+	p.leaf(n)
+	if strings.TrimSpace(n.text) == "" {
+		p.errorf(n, "empty regex in <%s>", n.name)
+	}
+	return &Regex{
+		Line:    n.line,
+		Pattern: n.text,
+		Options: p.regexOptions(n, a),
+	}
+}
+
+// Attributes shared by the contexts that are neither references nor
+// sub-patterns.
+var contextAttrs = []string{
+	"id", "style-ref", "class", "class-disabled", "extend-parent",
+	"end-parent", "first-line-only", "once-only",
+}
+
+// context parses a <context> element. parent is the context whose
+// <include> contains n, or nil for a context of <definitions>.
+func (p *parser) context(n *node, parent *Context) *Context {
+	c := &Context{Line: n.line, ExtendParent: true}
+	p.noText(n)
+
+	switch {
+	case hasAttr(n, "ref"):
+		c.Kind = ReferenceContext
+		a := p.attrs(n, "ref", "style-ref", "ignore-style", "original")
+		c.Ref = a["ref"]
+		c.StyleRef = a["style-ref"]
+		c.IgnoreStyle = p.boolAttr(n, a, "ignore-style", false)
+		c.Original = p.boolAttr(n, a, "original", false)
+		if parent == nil {
+			p.errorf(n, "reference context not allowed in <definitions>")
+		}
+		p.leaf(n)
+		return c
+
+	case hasAttr(n, "sub-pattern"):
+		c.Kind = SubPatternContext
+		a := p.attrs(n, "sub-pattern", "where", "style-ref", "class", "class-disabled")
+		c.SubPattern = a["sub-pattern"]
+		c.Where = a["where"]
+		c.StyleRef = a["style-ref"]
+		c.Class = strings.Fields(a["class"])
+		c.ClassDisabled = strings.Fields(a["class-disabled"])
+		switch {
+		case parent == nil:
+			p.errorf(n, "sub-pattern context not allowed in <definitions>")
+		case parent.Kind != SimpleContext && parent.Kind != ContainerContext:
+			p.errorf(n, "sub-pattern context not allowed in %s context", parent.Kind)
+		case c.Where != "" && c.Where != "start" && c.Where != "end":
+			p.errorf(n, "attribute \"where\" must be \"start\" or \"end\", not %q", c.Where)
+		case c.Where != "" && parent.Kind == SimpleContext:
+			p.errorf(n, "attribute \"where\" not allowed in simple context")
+		case c.Where == "" && parent.Kind == ContainerContext:
+			p.errorf(n, "sub-pattern context in container context is missing the \"where\" attribute")
+		}
+		p.leaf(n)
+		return c
+	}
+
+	c.Kind = ContainerContext
+	for _, child := range n.children {
+		switch child.name {
+		case "match":
+			c.Kind = SimpleContext
+		case "keyword":
+			c.Kind = KeywordContext
+		}
+	}
+
+	allowed := contextAttrs
+	if c.Kind == ContainerContext {
+		allowed = append(allowed[:len(allowed):len(allowed)], "style-inside", "end-at-line-end")
+	}
+	a := p.attrs(n, allowed...)
+	c.ID = a["id"]
+	c.StyleRef = a["style-ref"]
+	c.Class = strings.Fields(a["class"])
+	c.ClassDisabled = strings.Fields(a["class-disabled"])
+	c.ExtendParent = p.boolAttr(n, a, "extend-parent", true)
+	c.EndParent = p.boolAttr(n, a, "end-parent", false)
+	c.EndAtLineEnd = p.boolAttr(n, a, "end-at-line-end", false)
+	c.FirstLineOnly = p.boolAttr(n, a, "first-line-only", false)
+	c.OnceOnly = p.boolAttr(n, a, "once-only", false)
+	c.StyleInside = p.boolAttr(n, a, "style-inside", false)
+	if parent == nil && c.ID == "" {
+		p.errorf(n, "context in <definitions> is missing the \"id\" attribute")
+	}
+	if c.ID != "" && !idPattern.MatchString(c.ID) {
+		p.errorf(n, "invalid context id %q", c.ID)
+	}
+
+	seen := make(map[string]bool)
+	for _, child := range n.children {
+		switch {
+		case child.name == "match" && c.Kind == SimpleContext,
+			(child.name == "start" || child.name == "end") && c.Kind == ContainerContext:
+			if !p.once(seen, child) {
+				continue
+			}
+			r := p.regex(child, p.attrs(child, "case-sensitive", "extended", "dupnames"))
+			switch child.name {
+			case "match":
+				c.Match = r
+			case "start":
+				c.Start = r
+			case "end":
+				c.End = r
+			}
+		case (child.name == "prefix" || child.name == "suffix") && c.Kind == KeywordContext:
+			if !p.once(seen, child) {
+				continue
+			}
+			p.attrs(child)
+			if child.name == "prefix" {
+				c.Prefix = p.regex(child, nil)
+			} else {
+				c.Suffix = p.regex(child, nil)
+			}
+		case child.name == "keyword" && c.Kind == KeywordContext:
+			p.attrs(child)
+			c.Keywords = append(c.Keywords, p.regex(child, nil))
+		case child.name == "include" && c.Kind != KeywordContext:
+			if !p.once(seen, child) {
+				continue
+			}
+			p.include(child, c)
+		default:
+			p.errorf(child, "unexpected <%s> in %s context", child.name, c.Kind)
+		}
+	}
+	if c.End != nil && c.Start == nil {
+		p.errorf(n, "container context has <end> but no <start>")
+	}
+	return c
+}
+
+func (p *parser) include(n *node, c *Context) {
+	p.attrs(n)
+	p.noText(n)
+	for _, child := range n.children {
+		if child.name != "context" {
+			p.errorf(child, "unexpected <%s> in <include>", child.name)
+			continue
+		}
+		included := p.context(child, c)
+		if c.Kind == SimpleContext && included.Kind != SubPatternContext {
+			p.errorf(child, "simple context may only include sub-pattern contexts")
+		}
+		c.Include = append(c.Include, included)
+	}
+}
+
+// checkLocal checks the uniqueness of ids and the references to styles,
+// contexts and regexes of lang itself.
+func (p *parser) checkLocal(lang *Language) {
+	styles := make(map[string]bool)
+	for _, s := range lang.Styles {
+		if styles[s.ID] {
+			p.errs.add(p.filename, s.Line, "duplicate style id %q", s.ID)
+		}
+		styles[s.ID] = true
+	}
+
+	regexes := make(map[string]bool)
+	for _, r := range lang.Regexes {
+		if regexes[r.ID] {
+			p.errs.add(p.filename, r.Line, "duplicate regex id %q", r.ID)
+		}
+		regexes[r.ID] = true
+	}
+
+	contexts := make(map[string]bool)
+	lang.Walk(func(c *Context) bool {
+		if c.ID != "" {
+			if contexts[c.ID] {
+				p.errs.add(p.filename, c.Line, "duplicate context id %q", c.ID)
+			}
+			contexts[c.ID] = true
+		}
+		return true
+	})
+
+	for _, ref := range references(lang) {
+		langID, id, ok := splitRef(ref.kind, ref.name)
+		if !ok {
+			p.errs.add(p.filename, ref.line, "malformed %s reference %q", ref.kind, ref.name)
+			continue
+		}
+		if langID != "" && langID != lang.ID {
+			continue
+		}
+		var defined bool
+		switch ref.kind {
+		case styleRef:
+			defined = styles[id]
+		case contextRef:
+			defined = contexts[id]
+		case regexRef:
+			defined = regexes[id]
+		}
+		if !defined {
+			p.errs.add(p.filename, ref.line, "reference to undefined %s %q", ref.kind, ref.name)
+		}
+	}
+}
+
+// refKind is the namespace a reference is resolved in.
+type refKind int
+
+const (
+	styleRef refKind = iota
+	contextRef
+	regexRef
+)
+
+func (k refKind) String() string {
+	switch k {
+	case styleRef:
+		return "style"
+	case contextRef:
+		return "context"
+	}
+	return "regex"
+}
+
+// reference is a use of a style, context or regex id, which may be
+// qualified with the id of another language as in "def:comment".
+type reference struct {
+	kind refKind
+	name string
+	line int
+}
+
+// regexRefPattern matches the \%{id} references of a regex. References to
+// the start sub-patterns of a container, \%{name@start}, are excluded.
+var regexRefPattern = regexp.MustCompile(`\\%\{([^}@]+)\}`)
+
+// references returns the references made by lang to styles, contexts and
+// regexes.
+func references(lang *Language) []reference {
+	var refs []reference
+	addRegex := func(r *Regex) {
+		if r == nil {
+			return
+		}
+		for _, m := range regexRefPattern.FindAllStringSubmatch(r.Pattern, -1) {
+			refs = append(refs, reference{regexRef, m[1], r.Line})
+		}
+	}
+
+	for _, s := range lang.Styles {
+		if s.MapTo != "" {
+			refs = append(refs, reference{styleRef, s.MapTo, s.Line})
+		}
+	}
+	for _, r := range lang.Regexes {
+		addRegex(&r.Regex)
+	}
+	for _, r := range lang.Replaces {
+		refs = append(refs,
+			reference{contextRef, r.ID, r.Line},
+			reference{contextRef, r.Ref, r.Line})
+	}
+	lang.Walk(func(c *Context) bool {
+		if c.StyleRef != "" {
+			refs = append(refs, reference{styleRef, c.StyleRef, c.Line})
+		}
+		if c.Kind == ReferenceContext {
+			refs = append(refs, reference{contextRef, c.Ref, c.Line})
+		}
+		addRegex(c.Match)
+		addRegex(c.Start)
+		addRegex(c.End)
+		addRegex(c.Prefix)
+		addRegex(c.Suffix)
+		for _, k := range c.Keywords {
+			addRegex(k)
+		}
+		return true
+	})
+	return refs
+}
+
+// splitRef splits a reference of the given kind into the id of the language
+// it refers to, empty for the language making it, and the referenced id. A
+// context reference may end in ":*", including the children of the context
+// rather than the context itself; the suffix is dropped. ok is false if ref
+// is malformed.
+func splitRef(kind refKind, ref string) (lang, id string, ok bool) {
+	if kind == contextRef {
+		ref = strings.TrimSuffix(ref, ":*")
+	}
+	parts := strings.Split(ref, ":")
+	switch len(parts) {
+	case 1:
+		return "", parts[0], parts[0] != ""
+	case 2:
+		return parts[0], parts[1], parts[0] != "" && parts[1] != ""
+	}
+	return "", "", false
+}
+
+func hasAttr(n *node, name string) bool {
+	for _, a := range n.attrs {
+		if a.Name.Space == "" && a.Name.Local == name {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,411 @@
+package langspec
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// errorLines returns the errors of err as "line: message" strings.
+func errorLines(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var list ErrorList
+	if !errors.As(err, &list) {
+		t.Fatalf("error is %T, want ErrorList: %v", err, err)
+	}
+	var lines []string
+	for _, e := range list {
+		lines = append(lines, fmt.Sprintf("%d: %s", e.Line, e.Msg))
+	}
+	return lines
+}
+
+// doc returns a language definition whose <definitions> start with defs at
+// line 6.
+func doc(defs string) string {
+	return `<language id="t" name="T" version="2.0">
+  <styles>
+    <style id="s" name="S"/>
+  </styles>
+  <definitions>
+` + defs + `
+  </definitions>
+</language>
+`
+}
+
+const validLang = `<?xml version="1.0" encoding="UTF-8"?>
+<language id="mini" _name="Mini" version="2.0" _section="Source" hidden="true">
+  <metadata>
+    <property name="globs">*.mini</property>
+    <property name="line-comment-start">//</property>
+  </metadata>
+  <styles>
+    <style id="comment" name="Comment"/>
+    <style id="keyword" name="Keyword"/>
+  </styles>
+  <default-regex-options case-sensitive="false"/>
+  <keyword-char-class>[a-z]</keyword-char-class>
+  <definitions>
+    <define-regex id="ident" extended="true">[a-z]+</define-regex>
+    <context id="line-comment" style-ref="comment" end-at-line-end="true" class="comment no-spell-check">
+      <start>//</start>
+    </context>
+    <context id="string" style-inside="true">
+      <start>(?P&lt;q&gt;")</start>
+      <end>\%{q@start}</end>
+      <include>
+        <context sub-pattern="q" where="start" style-ref="keyword"/>
+        <context id="escape" extend-parent="false">
+          <match>\\.</match>
+        </context>
+      </include>
+    </context>
+    <context id="keywords" style-ref="keyword">
+      <prefix>\%[</prefix>
+      <keyword>if</keyword>
+      <keyword>\%{ident}_t</keyword>
+    </context>
+    <context id="mini">
+      <include>
+        <context ref="line-comment"/>
+        <context ref="string" ignore-style="true"/>
+        <context ref="keywords:*"/>
+        <context ref="mini:escape"/>
+      </include>
+    </context>
+    <replace id="escape" ref="string"/>
+  </definitions>
+</language>
+`
+
+func TestParseValid(t *testing.T) {
+	lang, err := Parse("mini.lang", strings.NewReader(validLang))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+
+	if lang.ID != "mini" || lang.Name != "Mini" || lang.Section != "Source" ||
+		!lang.Hidden || lang.Line != 2 {
+		t.Errorf("language = %q %q %q hidden=%t line %d", lang.ID, lang.Name,
+			lang.Section, lang.Hidden, lang.Line)
+	}
+	wantMetadata := map[string]string{"globs": "*.mini", "line-comment-start": "//"}
+	if !reflect.DeepEqual(lang.Metadata, wantMetadata) {
+		t.Errorf("Metadata = %v, want %v", lang.Metadata, wantMetadata)
+	}
+	if cs := lang.DefaultRegexOptions.CaseSensitive; cs == nil || *cs {
+		t.Errorf("DefaultRegexOptions.CaseSensitive = %v, want false", cs)
+	}
+	if lang.KeywordCharClass != "[a-z]" {
+		t.Errorf("KeywordCharClass = %q", lang.KeywordCharClass)
+	}
+	if len(lang.Styles) != 2 || lang.Style("keyword").Line != 9 {
+		t.Errorf("Styles = %v", lang.Styles)
+	}
+	if r := lang.Regex("ident"); r == nil || r.Pattern != "[a-z]+" || r.Line != 14 ||
+		r.Options.Extended == nil || !*r.Options.Extended {
+		t.Errorf("Regex(ident) = %+v", r)
+	}
+
+	kinds := map[string]ContextKind{
+		"line-comment": ContainerContext,
+		"string":       ContainerContext,
+		"escape":       SimpleContext,
+		"keywords":     KeywordContext,
+		"mini":         ContainerContext,
+	}
+	for id, kind := range kinds {
+		if c := lang.Context(id); c == nil || c.Kind != kind {
+			t.Errorf("Context(%q) = %+v, want kind %v", id, c, kind)
+		}
+	}
+
+	comment := lang.Context("line-comment")
+	if !comment.EndAtLineEnd || !reflect.DeepEqual(comment.Class, []string{"comment", "no-spell-check"}) ||
+		comment.Start.Pattern != "//" || comment.End != nil {
+		t.Errorf("line-comment = %+v", comment)
+	}
+	str := lang.Context("string")
+	if !str.StyleInside || str.Start.Pattern != `(?P<q>")` || str.End.Line != 20 ||
+		len(str.Include) != 2 || str.Include[0].Kind != SubPatternContext ||
+		str.Include[0].Where != "start" {
+		t.Errorf("string = %+v", str)
+	}
+	if lang.Context("escape").ExtendParent || !str.ExtendParent {
+		t.Errorf("extend-parent not parsed")
+	}
+	kw := lang.Context("keywords")
+	if len(kw.Keywords) != 2 || kw.Keywords[1].Pattern != `\%{ident}_t` || kw.Prefix == nil {
+		t.Errorf("keywords = %+v", kw)
+	}
+	main := lang.Context("mini")
+	if len(main.Include) != 4 || main.Include[2].Ref != "keywords:*" || !main.Include[1].IgnoreStyle {
+		t.Errorf("mini = %+v", main)
+	}
+	if len(lang.Replaces) != 1 || lang.Replaces[0].Line != 41 {
+		t.Errorf("Replaces = %+v", lang.Replaces)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{{
+		name: "syntax error",
+		src:  "<language id=\"t\">\n<definitions>\n</language>",
+		want: []string{"3: element <definitions> closed by </language>"},
+	}, {
+		name: "wrong root",
+		src:  "<lang/>",
+		want: []string{"1: root element is <lang>, want <language>"},
+	}, {
+		name: "language attributes",
+		src:  "<language name=\"T\" version=\"1.0\" id-x=\"t\">\n<definitions/>\n</language>",
+		want: []string{
+			`1: unexpected attribute "id-x" on <language>`,
+			`1: <language> is missing the "id" attribute`,
+			`1: unsupported language version "1.0", want "2.0"`,
+		},
+	}, {
+		name: "invalid language id",
+		src:  "<language id=\"a b\" name=\"T\" version=\"2.0\">\n<definitions/>\n</language>",
+		want: []string{`1: invalid language id "a b"`},
+	}, {
+		name: "missing definitions",
+		src:  "<language id=\"t\" name=\"T\" version=\"2.0\">\n  <styles/>\n  <styles/>\n</language>",
+		want: []string{
+			"1: <language> is missing <definitions>",
+			"3: duplicate <styles>",
+		},
+	}, {
+		name: "unexpected element",
+		src:  doc(`    <context id="a"><include/></context>` + "\n    <style id=\"x\"/>"),
+		want: []string{"7: unexpected <style> in <definitions>"},
+	}, {
+		name: "unexpected attribute",
+		src:  doc(`    <context id="a" bogus="1"/>`),
+		want: []string{`6: unexpected attribute "bogus" on <context>`},
+	}, {
+		name: "invalid boolean",
+		src:  doc(`    <context id="a" once-only="yes"/>`),
+		want: []string{`6: attribute "once-only" of <context> must be "true" or "false", not "yes"`},
+	}, {
+		name: "top-level context without id",
+		src:  doc(`    <context><match>a</match></context>`),
+		want: []string{`6: context in <definitions> is missing the "id" attribute`},
+	}, {
+		name: "top-level reference",
+		src:  doc(`    <context ref="a"/>`),
+		want: []string{
+			"6: reference context not allowed in <definitions>",
+			`6: reference to undefined context "a"`,
+		},
+	}, {
+		name: "end without start",
+		src:  doc("    <context id=\"a\">\n      <end>b</end>\n    </context>"),
+		want: []string{"6: container context has <end> but no <start>"},
+	}, {
+		name: "simple context including a context",
+		src: doc(`    <context id="a">
+      <match>a</match>
+      <include>
+        <context id="b"><match>b</match></context>
+      </include>
+    </context>`),
+		want: []string{"9: simple context may only include sub-pattern contexts"},
+	}, {
+		name: "sub-pattern where",
+		src: doc(`    <context id="a">
+      <start>a</start>
+      <include>
+        <context sub-pattern="0" where="begin"/>
+        <context sub-pattern="0"/>
+      </include>
+    </context>
+    <context id="b">
+      <match>b</match>
+      <include>
+        <context sub-pattern="0" where="start"/>
+      </include>
+    </context>`),
+		want: []string{
+			`9: attribute "where" must be "start" or "end", not "begin"`,
+			`10: sub-pattern context in container context is missing the "where" attribute`,
+			`16: attribute "where" not allowed in simple context`,
+		},
+	}, {
+		name: "keyword context with include",
+		src:  doc("    <context id=\"a\">\n      <keyword>a</keyword>\n      <include/>\n    </context>"),
+		want: []string{"8: unexpected <include> in keyword context"},
+	}, {
+		name: "container attribute on simple context",
+		src:  doc(`    <context id="a" style-inside="true"><match>a</match></context>`),
+		want: []string{`6: unexpected attribute "style-inside" on <context>`},
+	}, {
+		name: "empty regex",
+		src:  doc("    <context id=\"a\">\n      <match> </match>\n    </context>"),
+		want: []string{"7: empty regex in <match>"},
+	}, {
+		name: "duplicate match",
+		src:  doc("    <context id=\"a\">\n      <match>a</match>\n      <match>b</match>\n    </context>"),
+		want: []string{"8: duplicate <match>"},
+	}, {
+		name: "unexpected text",
+		src:  doc(`    <context id="a">text<include/></context>`),
+		want: []string{"6: unexpected text in <context>"},
+	}, {
+		name: "element in regex",
+		src:  doc("    <context id=\"a\">\n      <match>a<b/></match>\n    </context>"),
+		want: []string{"7: unexpected <b> in <match>"},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("t.lang", strings.NewReader(tt.src))
+			if got := errorLines(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		defs string
+		want []string
+	}{{
+		name: "duplicate context id",
+		defs: `    <context id="a">
+      <include>
+        <context id="a"><match>a</match></context>
+      </include>
+    </context>`,
+		want: []string{`8: duplicate context id "a"`},
+	}, {
+		name: "duplicate regex id",
+		defs: "    <define-regex id=\"r\">a</define-regex>\n    <define-regex id=\"r\">b</define-regex>",
+		want: []string{`7: duplicate regex id "r"`},
+	}, {
+		name: "invalid context id",
+		defs: `    <context id="a.b"><match>a</match></context>`,
+		want: []string{`6: invalid context id "a.b"`},
+	}, {
+		name: "defined references",
+		defs: `    <context id="a" style-ref="s"><match>a</match></context>
+    <context id="b" style-ref="t:s">
+      <include>
+        <context ref="a"/>
+        <context ref="t:a"/>
+      </include>
+    </context>
+    <replace id="a" ref="t:b"/>`,
+	}, {
+		name: "undefined references",
+		defs: `    <context id="a" style-ref="x"><match>a</match></context>
+    <context id="b" style-ref="t:y">
+      <include>
+        <context ref="c"/>
+        <context ref="t:d"/>
+      </include>
+    </context>
+    <replace id="e" ref="b"/>`,
+		want: []string{
+			`6: reference to undefined style "x"`,
+			`7: reference to undefined style "t:y"`,
+			`9: reference to undefined context "c"`,
+			`10: reference to undefined context "t:d"`,
+			`13: reference to undefined context "e"`,
+		},
+	}, {
+		name: "regex references",
+		defs: `    <define-regex id="r">a</define-regex>
+    <define-regex id="q">\%{r}\%{t:r}\%{missing}</define-regex>
+    <context id="a">
+      <start>(?P&lt;x&gt;a)\%{q}</start>
+      <end>\%{x@start}\%{t:nope}</end>
+    </context>`,
+		want: []string{
+			`7: reference to undefined regex "missing"`,
+			`10: reference to undefined regex "t:nope"`,
+		},
+	}, {
+		name: "children references",
+		defs: `    <context id="a"><keyword>a</keyword></context>
+    <context id="b">
+      <include>
+        <context ref="a:*"/>
+        <context ref="t:a:*"/>
+        <context ref="c:*"/>
+      </include>
+    </context>`,
+		want: []string{`11: reference to undefined context "c:*"`},
+	}, {
+		name: "malformed references",
+		defs: `    <context id="a" style-ref="t:s:*"><match>a</match></context>
+    <context id="b">
+      <include>
+        <context ref=""/>
+        <context ref=":a"/>
+        <context ref="t:"/>
+        <context ref="x:t:a"/>
+        <context ref="t:a:*:*"/>
+      </include>
+    </context>`,
+		want: []string{
+			`6: malformed style reference "t:s:*"`,
+			`9: malformed context reference ""`,
+			`10: malformed context reference ":a"`,
+			`11: malformed context reference "t:"`,
+			`12: malformed context reference "x:t:a"`,
+			`13: malformed context reference "t:a:*:*"`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse("t.lang", strings.NewReader(doc(tt.defs)))
+			if got := errorLines(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestParseDuplicateStyle(t *testing.T) {
+	src := `<language id="t" name="T" version="2.0">
+  <styles>
+    <style id="s" name="S"/>
+    <style id="s" name="S2"/>
+    <style id="u" name="U" map-to="v"/>
+  </styles>
+  <definitions/>
+</language>`
+	_, err := Parse("t.lang", strings.NewReader(src))
+	want := []string{
+		`4: duplicate style id "s"`,
+		`5: reference to undefined style "v"`,
+	}
+	if got := errorLines(t, err); !reflect.DeepEqual(got, want) {
+		t.Errorf("errors = %q, want %q", got, want)
+	}
+}
+
+func TestErrorListError(t *testing.T) {
+	list := ErrorList{{"a.lang", 3, "first"}, {"a.lang", 5, "second"}}
+	if got, want := list.Error(), "a.lang:3: first (and 1 more errors)"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if got, want := list[:1].Error(), "a.lang:3: first"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	if ErrorList(nil).Err() != nil {
+		t.Errorf("empty ErrorList.Err() != nil")
+	}
+}
@@ -0,0 +1,161 @@
+package langspec
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// DefaultSearchPath returns the directories searched for language
+// definitions by a default SourceLanguageManager: the gtksourceview-3.0
+// language-specs directory of the user data directory, followed by those
+// of the system data directories.
+func DefaultSearchPath() []string {
+	const subdir = "gtksourceview-3.0/language-specs"
+
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" {
+		if home, err := os.UserHomeDir(); err == nil {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+	}
+	dataDirs := os.Getenv("XDG_DATA_DIRS")
+	if dataDirs == "" {
+		dataDirs = "/usr/local/share:/usr/share"
+	}
+
+	var paths []string
+	if dataHome != "" {
+		paths = append(paths, filepath.Join(dataHome, subdir))
+	}
+	for _, dir := range filepath.SplitList(dataDirs) {
+		if dir != "" {
+			paths = append(paths, filepath.Join(dir, subdir))
+		}
+	}
+	return paths
+}
+
+// Resolver checks references between languages, loading the languages
+// referred to from the directories of SearchPath as needed. The search path
+// of a SourceLanguageManager, from its GetSearchPath method, can be used to
+// resolve references the same way it does.
+type Resolver struct {
+	SearchPath []string
+
+	langs map[string]*Language
+	errs  map[string]error
+}
+
+// NewResolver returns a Resolver loading languages from searchPath.
+func NewResolver(searchPath []string) *Resolver {
+	return &Resolver{
+		SearchPath: searchPath,
+		langs:      make(map[string]*Language),
+		errs:       make(map[string]error),
+	}
+}
+
+// Add makes lang available to resolve references, taking precedence over
+// any language with the same id in the search path.
+func (r *Resolver) Add(lang *Language) {
+	r.langs[lang.ID] = lang
+	delete(r.errs, lang.ID)
+}
+
+// Language returns the language with the given id, loading it from the
+// file id + ".lang" in the first directory of the search path that has one.
+func (r *Resolver) Language(id string) (*Language, error) {
+	if lang, ok := r.langs[id]; ok {
+		return lang, nil
+	}
+	if err, ok := r.errs[id]; ok {
+		return nil, err
+	}
+
+	lang, err := r.load(id)
+	if err != nil {
+		r.errs[id] = err
+		return nil, err
+	}
+	r.langs[id] = lang
+	return lang, nil
+}
+
+func (r *Resolver) load(id string) (*Language, error) {
+	for _, dir := range r.SearchPath {
+		lang, err := ParseFile(filepath.Join(dir, id+".lang"))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if lang == nil {
+			return nil, err
+		}
+		if lang.ID != id {
+			return nil, fmt.Errorf("%s defines language %q, not %q", lang.Filename, lang.ID, id)
+		}
+		// A language with errors of its own can still resolve the
+		// references made to it; those errors are reported when it is
+		// validated itself.
+		return lang, nil
+	}
+	return nil, fmt.Errorf("language %q not found in search path", id)
+}
+
+// Resolve checks that the references made by lang to other languages, such
+// as style-ref="def:comment" or ref="def:in-comment:*", name styles,
+// contexts and regexes those languages define. lang is added to r first.
+func (r *Resolver) Resolve(lang *Language) error {
+	r.Add(lang)
+
+	var errs ErrorList
+	for _, ref := range references(lang) {
+		// Malformed references are reported by Parse.
+		langID, id, ok := splitRef(ref.kind, ref.name)
+		if !ok || langID == "" || langID == lang.ID {
+			continue
+		}
+		other, err := r.Language(langID)
+		if err != nil {
+			errs.add(lang.Filename, ref.line, "cannot resolve %s %q: %v", ref.kind, ref.name, err)
+			continue
+		}
+
+		var defined bool
+		switch ref.kind {
+		case styleRef:
+			defined = other.Style(id) != nil
+		case contextRef:
+			defined = other.Context(id) != nil
+		case regexRef:
+			defined = other.Regex(id) != nil
+		}
+		if !defined {
+			errs.add(lang.Filename, ref.line, "reference to undefined %s %q", ref.kind, ref.name)
+		}
+	}
+	errs.Sort()
+	return errs.Err()
+}
+
+// ValidateFile parses the named language definition and resolves its
+// references to other languages against searchPath, returning all errors
+// found as an ErrorList.
+func ValidateFile(filename string, searchPath []string) error {
+	lang, err := ParseFile(filename)
+	if lang == nil {
+		return err
+	}
+
+	var errs ErrorList
+	var list ErrorList
+	if errors.As(err, &list) {
+		errs = append(errs, list...)
+	}
+	if errors.As(NewResolver(searchPath).Resolve(lang), &list) {
+		errs = append(errs, list...)
+	}
+	errs.Sort()
+	return errs.Err()
+}
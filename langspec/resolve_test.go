@@ -0,0 +1,159 @@
+package langspec
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const defLang = `<language id="def" name="Default" version="2.0" hidden="true">
+  <styles>
+    <style id="comment" name="Comment"/>
+    <style id="string" name="String"/>
+  </styles>
+  <definitions>
+    <define-regex id="escape">\\.</define-regex>
+    <context id="in-comment">
+      <include>
+        <context id="note"><keyword>TODO</keyword></context>
+      </include>
+    </context>
+  </definitions>
+</language>
+`
+
+// writeSpecs writes files to a new directory and returns it.
+func writeSpecs(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestResolve(t *testing.T) {
+	dir := writeSpecs(t, map[string]string{
+		"def.lang":   defLang,
+		"other.lang": strings.Replace(defLang, `id="def"`, `id="different"`, 1),
+	})
+
+	tests := []struct {
+		name string
+		defs string
+		want []string
+	}{{
+		name: "defined",
+		defs: `    <define-regex id="r">\%{def:escape}</define-regex>
+    <context id="a" style-ref="def:comment">
+      <start>#</start>
+      <include>
+        <context ref="def:in-comment"/>
+        <context ref="def:in-comment:*"/>
+        <context ref="def:note"/>
+      </include>
+    </context>`,
+	}, {
+		name: "undefined",
+		defs: `    <define-regex id="r">\%{def:nope}</define-regex>
+    <context id="a" style-ref="def:keyword">
+      <start>#</start>
+      <include>
+        <context ref="def:missing"/>
+        <context ref="def:missing:*"/>
+      </include>
+    </context>`,
+		want: []string{
+			`6: reference to undefined regex "def:nope"`,
+			`7: reference to undefined style "def:keyword"`,
+			`10: reference to undefined context "def:missing"`,
+			`11: reference to undefined context "def:missing:*"`,
+		},
+	}, {
+		name: "missing language",
+		defs: `    <context id="a" style-ref="nolang:x"><match>a</match></context>`,
+		want: []string{
+			`6: cannot resolve style "nolang:x": language "nolang" not found in search path`,
+		},
+	}, {
+		name: "id not matching file name",
+		defs: `    <context id="a"><include><context ref="other:in-comment"/></include></context>`,
+		want: []string{
+			`6: cannot resolve context "other:in-comment": ` + filepath.Join(dir, "other.lang") +
+				` defines language "different", not "other"`,
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang, err := Parse("t.lang", strings.NewReader(doc(tt.defs)))
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			err = NewResolver([]string{t.TempDir(), dir}).Resolve(lang)
+			if got := errorLines(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestResolverSearchPathOrder(t *testing.T) {
+	first := writeSpecs(t, map[string]string{
+		"def.lang": strings.Replace(defLang, `"comment"`, `"first"`, 1),
+	})
+	second := writeSpecs(t, map[string]string{"def.lang": defLang})
+
+	r := NewResolver([]string{first, second})
+	def, err := r.Language("def")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.Style("first") == nil {
+		t.Errorf("loaded %s, want the first directory of the search path", def.Filename)
+	}
+
+	added := &Language{ID: "def"}
+	r.Add(added)
+	if def, _ := r.Language("def"); def != added {
+		t.Errorf("Language did not return the added language")
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	dir := writeSpecs(t, map[string]string{
+		"def.lang": defLang,
+		"t.lang": doc(`    <context id="a" style-ref="def:nope" bogus="1"><match>a</match></context>
+    <context id="b" style-ref="undefined"><match>b</match></context>`),
+	})
+
+	err := ValidateFile(filepath.Join(dir, "t.lang"), []string{dir})
+	want := []string{
+		`6: unexpected attribute "bogus" on <context>`,
+		`6: reference to undefined style "def:nope"`,
+		`7: reference to undefined style "undefined"`,
+	}
+	if got := errorLines(t, err); !reflect.DeepEqual(got, want) {
+		t.Errorf("errors:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if err := ValidateFile(filepath.Join(dir, "def.lang"), []string{dir}); err != nil {
+		t.Errorf("ValidateFile(def.lang) = %v", err)
+	}
+}
+
+func TestDefaultSearchPath(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/home/u/.local/share")
+	t.Setenv("XDG_DATA_DIRS", "/usr/share:/opt/share")
+	want := []string{
+		"/home/u/.local/share/gtksourceview-3.0/language-specs",
+		"/usr/share/gtksourceview-3.0/language-specs",
+		"/opt/share/gtksourceview-3.0/language-specs",
+	}
+	if got := DefaultSearchPath(); !reflect.DeepEqual(got, want) {
+		t.Errorf("DefaultSearchPath() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,336 @@
+package sourceview
+
+// #cgo pkg-config: gtksourceview-3.0
+// #include <gtksourceview/gtksourcebuffer.h>
+// #include <gtksourceview/gtksourcemark.h>
+// #include <gtksourceview/gtksourcemarkattributes.h>
+// #include <gtksourceview/gtksourceview.h>
+// #include "sourceview.go.h"
+// #include "mark.go.h"
+import "C"
+import (
+	"unsafe"
+
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+func init() {
+	tm := []glib.TypeMarshaler{
+		{glib.Type(C.gtk_source_mark_get_type()), marshalSourceMark},
+		{glib.Type(C.gtk_source_mark_attributes_get_type()), marshalSourceMarkAttributes},
+	}
+	glib.RegisterGValueMarshalers(tm)
+
+	gtk.WrapMap["GtkSourceMark"] = wrapSourceMark
+	gtk.WrapMap["GtkSourceMarkAttributes"] = wrapSourceMarkAttributes
+}
+
+/*
+ * GtkSourceMark
+ */
+
+// SourceMark is a representation of GtkSourceMark.
+type SourceMark struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GtkSourceMark.
+func (v *SourceMark) native() *C.GtkSourceMark {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceMark(p)
+}
+
+func marshalSourceMark(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceMark(obj), nil
+}
+
+func wrapSourceMark(obj *glib.Object) *SourceMark {
+	return &SourceMark{obj}
+}
+
+// SourceMarkNew is a wrapper around gtk_source_mark_new().
+// An empty name creates an anonymous mark.
+func SourceMarkNew(name, category string) (*SourceMark, error) {
+	cname := cstringOrNil(name)
+	defer C.free(unsafe.Pointer(cname))
+	ccategory := C.CString(category)
+	defer C.free(unsafe.Pointer(ccategory))
+
+	c := C.gtk_source_mark_new(cname, (*C.gchar)(ccategory))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceMark(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// TextMark returns v as the GtkTextMark it derives from, for use with the
+// gtk.TextBuffer mark functions.
+func (v *SourceMark) TextMark() *gtk.TextMark {
+	return (*gtk.TextMark)(unsafe.Pointer(v.native()))
+}
+
+// GetCategory is a wrapper around gtk_source_mark_get_category().
+func (v *SourceMark) GetCategory() string {
+	return goString(C.gtk_source_mark_get_category(v.native()))
+}
+
+// Next is a wrapper around gtk_source_mark_next().
+// An empty category matches marks of any category. It returns nil if there
+// is no next mark.
+func (v *SourceMark) Next(category string) *SourceMark {
+	ccategory := cstringOrNil(category)
+	defer C.free(unsafe.Pointer(ccategory))
+
+	c := C.gtk_source_mark_next(v.native(), ccategory)
+	if c == nil {
+		return nil
+	}
+	return wrapSourceMark(glib.Take(unsafe.Pointer(c)))
+}
+
+// Prev is a wrapper around gtk_source_mark_prev().
+// An empty category matches marks of any category. It returns nil if there
+// is no previous mark.
+func (v *SourceMark) Prev(category string) *SourceMark {
+	ccategory := cstringOrNil(category)
+	defer C.free(unsafe.Pointer(ccategory))
+
+	c := C.gtk_source_mark_prev(v.native(), ccategory)
+	if c == nil {
+		return nil
+	}
+	return wrapSourceMark(glib.Take(unsafe.Pointer(c)))
+}
+
+// goSourceMarks converts list, a GSList of GtkSourceMarks, to a slice and
+// frees the list.
+func goSourceMarks(list *C.GSList) []*SourceMark {
+	defer C.g_slist_free(list)
+
+	var marks []*SourceMark
+	for l := list; l != nil; l = l.next {
+		marks = append(marks, wrapSourceMark(glib.Take(unsafe.Pointer(l.data))))
+	}
+	return marks
+}
+
+// CreateSourceMark is a wrapper around gtk_source_buffer_create_source_mark().
+// An empty name creates an anonymous mark.
+func (v *SourceBuffer) CreateSourceMark(name, category string, where *gtk.TextIter) (*SourceMark, error) {
+	cname := cstringOrNil(name)
+	defer C.free(unsafe.Pointer(cname))
+	ccategory := C.CString(category)
+	defer C.free(unsafe.Pointer(ccategory))
+
+	c := C.gtk_source_buffer_create_source_mark(v.native(), cname, (*C.gchar)(ccategory),
+		nativeTextIter(where))
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceMark(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetSourceMarksAtLine is a wrapper around gtk_source_buffer_get_source_marks_at_line().
+// An empty category matches marks of any category.
+func (v *SourceBuffer) GetSourceMarksAtLine(line int, category string) []*SourceMark {
+	ccategory := cstringOrNil(category)
+	defer C.free(unsafe.Pointer(ccategory))
+
+	return goSourceMarks(C.gtk_source_buffer_get_source_marks_at_line(v.native(), C.gint(line), ccategory))
+}
+
+// GetSourceMarksAtIter is a wrapper around gtk_source_buffer_get_source_marks_at_iter().
+// An empty category matches marks of any category.
+func (v *SourceBuffer) GetSourceMarksAtIter(iter *gtk.TextIter, category string) []*SourceMark {
+	ccategory := cstringOrNil(category)
+	defer C.free(unsafe.Pointer(ccategory))
+
+	return goSourceMarks(C.gtk_source_buffer_get_source_marks_at_iter(v.native(), nativeTextIter(iter), ccategory))
+}
+
+// RemoveSourceMarks is a wrapper around gtk_source_buffer_remove_source_marks().
+// An empty category removes marks of any category.
+func (v *SourceBuffer) RemoveSourceMarks(start, end *gtk.TextIter, category string) {
+	ccategory := cstringOrNil(category)
+	defer C.free(unsafe.Pointer(ccategory))
+
+	C.gtk_source_buffer_remove_source_marks(v.native(), nativeTextIter(start), nativeTextIter(end), ccategory)
+}
+
+// ForwardIterToSourceMark is a wrapper around gtk_source_buffer_forward_iter_to_source_mark().
+// An empty category matches marks of any category. It returns false if iter
+// was not moved.
+func (v *SourceBuffer) ForwardIterToSourceMark(iter *gtk.TextIter, category string) bool {
+	ccategory := cstringOrNil(category)
+	defer C.free(unsafe.Pointer(ccategory))
+
+	return gobool(C.gtk_source_buffer_forward_iter_to_source_mark(v.native(), nativeTextIter(iter), ccategory))
+}
+
+// BackwardIterToSourceMark is a wrapper around gtk_source_buffer_backward_iter_to_source_mark().
+// An empty category matches marks of any category. It returns false if iter
+// was not moved.
+func (v *SourceBuffer) BackwardIterToSourceMark(iter *gtk.TextIter, category string) bool {
+	ccategory := cstringOrNil(category)
+	defer C.free(unsafe.Pointer(ccategory))
+
+	return gobool(C.gtk_source_buffer_backward_iter_to_source_mark(v.native(), nativeTextIter(iter), ccategory))
+}
+
+/*
+ * GtkSourceMarkAttributes
+ */
+
+// SourceMarkAttributes is a representation of GtkSourceMarkAttributes.
+type SourceMarkAttributes struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GtkSourceMarkAttributes.
+func (v *SourceMarkAttributes) native() *C.GtkSourceMarkAttributes {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceMarkAttributes(p)
+}
+
+func marshalSourceMarkAttributes(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceMarkAttributes(obj), nil
+}
+
+func wrapSourceMarkAttributes(obj *glib.Object) *SourceMarkAttributes {
+	return &SourceMarkAttributes{obj}
+}
+
+// SourceMarkAttributesNew is a wrapper around gtk_source_mark_attributes_new().
+func SourceMarkAttributesNew() (*SourceMarkAttributes, error) {
+	c := C.gtk_source_mark_attributes_new()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceMarkAttributes(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetBackground is a wrapper around gtk_source_mark_attributes_get_background().
+// The returned bool is false if no background color is set.
+func (v *SourceMarkAttributes) GetBackground() (*gdk.RGBA, bool) {
+	var background C.GdkRGBA
+	c := C.gtk_source_mark_attributes_get_background(v.native(), &background)
+	if !gobool(c) {
+		return nil, false
+	}
+	return gdk.WrapRGBA(unsafe.Pointer(&background)), true
+}
+
+// SetBackground is a wrapper around gtk_source_mark_attributes_set_background().
+// A nil background unsets the background.
+func (v *SourceMarkAttributes) SetBackground(background *gdk.RGBA) {
+	var cbackground *C.GdkRGBA
+	if background != nil {
+		cbackground = (*C.GdkRGBA)(unsafe.Pointer(background.Native()))
+	}
+	C.gtk_source_mark_attributes_set_background(v.native(), cbackground)
+}
+
+// GetIconName is a wrapper around gtk_source_mark_attributes_get_icon_name().
+func (v *SourceMarkAttributes) GetIconName() string {
+	c := C.gtk_source_mark_attributes_get_icon_name(v.native())
+	if c == nil {
+		return ""
+	}
+	return goString(c)
+}
+
+// SetIconName is a wrapper around gtk_source_mark_attributes_set_icon_name().
+func (v *SourceMarkAttributes) SetIconName(iconName string) {
+	cstr := C.CString(iconName)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_mark_attributes_set_icon_name(v.native(), (*C.gchar)(cstr))
+}
+
+// GetGIcon is a wrapper around gtk_source_mark_attributes_get_gicon().
+func (v *SourceMarkAttributes) GetGIcon() *glib.Object {
+	c := C.gtk_source_mark_attributes_get_gicon(v.native())
+	if c == nil {
+		return nil
+	}
+	return glib.Take(unsafe.Pointer(c))
+}
+
+// SetGIcon is a wrapper around gtk_source_mark_attributes_set_gicon().
+// gicon must implement the GIcon interface.
+func (v *SourceMarkAttributes) SetGIcon(gicon *glib.Object) {
+	C.gtk_source_mark_attributes_set_gicon(v.native(), nativeGIcon(gicon))
+}
+
+// GetPixbuf is a wrapper around gtk_source_mark_attributes_get_pixbuf().
+func (v *SourceMarkAttributes) GetPixbuf() *gdk.Pixbuf {
+	c := C.gtk_source_mark_attributes_get_pixbuf(v.native())
+	if c == nil {
+		return nil
+	}
+	return &gdk.Pixbuf{glib.Take(unsafe.Pointer(c))}
+}
+
+// SetPixbuf is a wrapper around gtk_source_mark_attributes_set_pixbuf().
+func (v *SourceMarkAttributes) SetPixbuf(pixbuf *gdk.Pixbuf) {
+	C.gtk_source_mark_attributes_set_pixbuf(v.native(), nativePixbuf(pixbuf))
+}
+
+// GetTooltipText is a wrapper around gtk_source_mark_attributes_get_tooltip_text().
+func (v *SourceMarkAttributes) GetTooltipText(mark *SourceMark) string {
+	return goStringFree(C.gtk_source_mark_attributes_get_tooltip_text(v.native(), mark.native()))
+}
+
+// GetTooltipMarkup is a wrapper around gtk_source_mark_attributes_get_tooltip_markup().
+func (v *SourceMarkAttributes) GetTooltipMarkup(mark *SourceMark) string {
+	return goStringFree(C.gtk_source_mark_attributes_get_tooltip_markup(v.native(), mark.native()))
+}
+
+// ConnectQueryTooltipText connects f to the "query-tooltip-text" signal. f
+// returns the plain text tooltip of mark.
+func (v *SourceMarkAttributes) ConnectQueryTooltipText(f func(mark *SourceMark) string) (glib.SignalHandle, error) {
+	return v.Connect("query-tooltip-text", func(_ *SourceMarkAttributes, mark *SourceMark) string {
+		return f(mark)
+	})
+}
+
+// ConnectQueryTooltipMarkup connects f to the "query-tooltip-markup" signal.
+// f returns the tooltip of mark in Pango markup.
+func (v *SourceMarkAttributes) ConnectQueryTooltipMarkup(f func(mark *SourceMark) string) (glib.SignalHandle, error) {
+	return v.Connect("query-tooltip-markup", func(_ *SourceMarkAttributes, mark *SourceMark) string {
+		return f(mark)
+	})
+}
+
+// SetMarkAttributes is a wrapper around gtk_source_view_set_mark_attributes().
+// Marks of a category with a higher priority are drawn on top.
+func (v *SourceView) SetMarkAttributes(category string, attributes *SourceMarkAttributes, priority int) {
+	cstr := C.CString(category)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_view_set_mark_attributes(v.native(), (*C.gchar)(cstr), attributes.native(), C.gint(priority))
+}
+
+// GetMarkAttributes is a wrapper around gtk_source_view_get_mark_attributes().
+// It returns nil if no attributes are set for category.
+func (v *SourceView) GetMarkAttributes(category string) (attributes *SourceMarkAttributes, priority int) {
+	cstr := C.CString(category)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var cpriority C.gint
+	c := C.gtk_source_view_get_mark_attributes(v.native(), (*C.gchar)(cstr), &cpriority)
+	if c == nil {
+		return nil, 0
+	}
+	return wrapSourceMarkAttributes(glib.Take(unsafe.Pointer(c))), int(cpriority)
+}
@@ -0,0 +1,11 @@
+static GtkSourceMark *
+toGtkSourceMark(void *p)
+{
+	return (GTK_SOURCE_MARK(p));
+}
+
+static GtkSourceMarkAttributes *
+toGtkSourceMarkAttributes(void *p)
+{
+	return (GTK_SOURCE_MARK_ATTRIBUTES(p));
+}
@@ -0,0 +1,352 @@
+package sourceview
+
+// #cgo pkg-config: gtksourceview-3.0
+// #include <gtksourceview/gtksourcebuffer.h>
+// #include <gtksourceview/gtksourcesearchcontext.h>
+// #include <gtksourceview/gtksourcesearchsettings.h>
+// #include <gtksourceview/gtksourcestyle.h>
+// #include "sourceview.go.h"
+// #include "search.go.h"
+import "C"
+import (
+	"context"
+	"errors"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+var errNotOccurrence = errors.New("bounds do not match a search occurrence")
+
+func init() {
+	tm := []glib.TypeMarshaler{
+		{glib.Type(C.gtk_source_search_context_get_type()), marshalSourceSearchContext},
+		{glib.Type(C.gtk_source_search_settings_get_type()), marshalSourceSearchSettings},
+	}
+	glib.RegisterGValueMarshalers(tm)
+
+	gtk.WrapMap["GtkSourceSearchContext"] = wrapSourceSearchContext
+	gtk.WrapMap["GtkSourceSearchSettings"] = wrapSourceSearchSettings
+}
+
+/*
+ * GtkSourceSearchSettings
+ */
+
+// SourceSearchSettings is a representation of GtkSourceSearchSettings.
+type SourceSearchSettings struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GtkSourceSearchSettings.
+func (v *SourceSearchSettings) native() *C.GtkSourceSearchSettings {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceSearchSettings(p)
+}
+
+func marshalSourceSearchSettings(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceSearchSettings(obj), nil
+}
+
+func wrapSourceSearchSettings(obj *glib.Object) *SourceSearchSettings {
+	return &SourceSearchSettings{obj}
+}
+
+// SourceSearchSettingsNew is a wrapper around gtk_source_search_settings_new().
+func SourceSearchSettingsNew() (*SourceSearchSettings, error) {
+	c := C.gtk_source_search_settings_new()
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceSearchSettings(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetSearchText is a wrapper around gtk_source_search_settings_get_search_text().
+// It returns an empty string when no search text is set.
+func (v *SourceSearchSettings) GetSearchText() string {
+	c := C.gtk_source_search_settings_get_search_text(v.native())
+	if c == nil {
+		return ""
+	}
+	return goString(c)
+}
+
+// SetSearchText is a wrapper around gtk_source_search_settings_set_search_text().
+// An empty string unsets the search text.
+func (v *SourceSearchSettings) SetSearchText(text string) {
+	if text == "" {
+		C.gtk_source_search_settings_set_search_text(v.native(), nil)
+		return
+	}
+	cstr := C.CString(text)
+	defer C.free(unsafe.Pointer(cstr))
+	C.gtk_source_search_settings_set_search_text(v.native(), (*C.gchar)(cstr))
+}
+
+// GetCaseSensitive is a wrapper around gtk_source_search_settings_get_case_sensitive().
+func (v *SourceSearchSettings) GetCaseSensitive() bool {
+	return gobool(C.gtk_source_search_settings_get_case_sensitive(v.native()))
+}
+
+// SetCaseSensitive is a wrapper around gtk_source_search_settings_set_case_sensitive().
+func (v *SourceSearchSettings) SetCaseSensitive(caseSensitive bool) {
+	C.gtk_source_search_settings_set_case_sensitive(v.native(), gbool(caseSensitive))
+}
+
+// GetAtWordBoundaries is a wrapper around gtk_source_search_settings_get_at_word_boundaries().
+func (v *SourceSearchSettings) GetAtWordBoundaries() bool {
+	return gobool(C.gtk_source_search_settings_get_at_word_boundaries(v.native()))
+}
+
+// SetAtWordBoundaries is a wrapper around gtk_source_search_settings_set_at_word_boundaries().
+func (v *SourceSearchSettings) SetAtWordBoundaries(atWordBoundaries bool) {
+	C.gtk_source_search_settings_set_at_word_boundaries(v.native(), gbool(atWordBoundaries))
+}
+
+// GetRegexEnabled is a wrapper around gtk_source_search_settings_get_regex_enabled().
+func (v *SourceSearchSettings) GetRegexEnabled() bool {
+	return gobool(C.gtk_source_search_settings_get_regex_enabled(v.native()))
+}
+
+// SetRegexEnabled is a wrapper around gtk_source_search_settings_set_regex_enabled().
+func (v *SourceSearchSettings) SetRegexEnabled(regexEnabled bool) {
+	C.gtk_source_search_settings_set_regex_enabled(v.native(), gbool(regexEnabled))
+}
+
+// GetWrapAround is a wrapper around gtk_source_search_settings_get_wrap_around().
+func (v *SourceSearchSettings) GetWrapAround() bool {
+	return gobool(C.gtk_source_search_settings_get_wrap_around(v.native()))
+}
+
+// SetWrapAround is a wrapper around gtk_source_search_settings_set_wrap_around().
+func (v *SourceSearchSettings) SetWrapAround(wrapAround bool) {
+	C.gtk_source_search_settings_set_wrap_around(v.native(), gbool(wrapAround))
+}
+
+/*
+ * GtkSourceSearchContext
+ */
+
+// SourceSearchContext is a representation of GtkSourceSearchContext.
+type SourceSearchContext struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GtkSourceSearchContext.
+func (v *SourceSearchContext) native() *C.GtkSourceSearchContext {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceSearchContext(p)
+}
+
+func marshalSourceSearchContext(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceSearchContext(obj), nil
+}
+
+func wrapSourceSearchContext(obj *glib.Object) *SourceSearchContext {
+	return &SourceSearchContext{obj}
+}
+
+// SourceSearchContextNew is a wrapper around gtk_source_search_context_new().
+// If settings is nil, a new SourceSearchSettings is created for the context.
+func SourceSearchContextNew(buffer *SourceBuffer, settings *SourceSearchSettings) (*SourceSearchContext, error) {
+	c := C.gtk_source_search_context_new(buffer.native(), settings.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceSearchContext(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetBuffer is a wrapper around gtk_source_search_context_get_buffer().
+func (v *SourceSearchContext) GetBuffer() (*SourceBuffer, error) {
+	c := C.gtk_source_search_context_get_buffer(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceBuffer(glib.Take(unsafe.Pointer(c)))
+}
+
+// GetSettings is a wrapper around gtk_source_search_context_get_settings().
+func (v *SourceSearchContext) GetSettings() (*SourceSearchSettings, error) {
+	c := C.gtk_source_search_context_get_settings(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceSearchSettings(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetHighlight is a wrapper around gtk_source_search_context_get_highlight().
+func (v *SourceSearchContext) GetHighlight() bool {
+	return gobool(C.gtk_source_search_context_get_highlight(v.native()))
+}
+
+// SetHighlight is a wrapper around gtk_source_search_context_set_highlight().
+func (v *SourceSearchContext) SetHighlight(highlight bool) {
+	C.gtk_source_search_context_set_highlight(v.native(), gbool(highlight))
+}
+
+// GetMatchStyle is a wrapper around gtk_source_search_context_get_match_style().
+func (v *SourceSearchContext) GetMatchStyle() *SourceStyle {
+	c := C.gtk_source_search_context_get_match_style(v.native())
+	if c == nil {
+		return nil
+	}
+	return wrapSourceStyle(glib.Take(unsafe.Pointer(c)))
+}
+
+// SetMatchStyle is a wrapper around gtk_source_search_context_set_match_style().
+// A nil style restores the style scheme's default match style.
+func (v *SourceSearchContext) SetMatchStyle(style *SourceStyle) {
+	C.gtk_source_search_context_set_match_style(v.native(), style.native())
+}
+
+// GetRegexError is a wrapper around gtk_source_search_context_get_regex_error().
+// It returns nil if regex search is disabled or the pattern is valid.
+func (v *SourceSearchContext) GetRegexError() error {
+	return goError(C.gtk_source_search_context_get_regex_error(v.native()))
+}
+
+// GetOccurrencesCount is a wrapper around gtk_source_search_context_get_occurrences_count().
+// It returns -1 while the buffer has not been fully scanned yet.
+func (v *SourceSearchContext) GetOccurrencesCount() int {
+	return int(C.gtk_source_search_context_get_occurrences_count(v.native()))
+}
+
+// GetOccurrencePosition is a wrapper around gtk_source_search_context_get_occurrence_position().
+// It returns 0 if the bounds are not an occurrence and -1 while the buffer
+// has not been fully scanned yet.
+func (v *SourceSearchContext) GetOccurrencePosition(matchStart, matchEnd *gtk.TextIter) int {
+	c := C.gtk_source_search_context_get_occurrence_position(v.native(),
+		nativeTextIter(matchStart), nativeTextIter(matchEnd))
+	return int(c)
+}
+
+// Forward is a wrapper around gtk_source_search_context_forward2().
+// The returned bool reports whether a match was found.
+func (v *SourceSearchContext) Forward(iter *gtk.TextIter) (matchStart, matchEnd *gtk.TextIter, hasWrappedAround, ok bool) {
+	var start, end C.GtkTextIter
+	var wrapped C.gboolean
+	c := C.gtk_source_search_context_forward2(v.native(), nativeTextIter(iter),
+		&start, &end, &wrapped)
+	if !gobool(c) {
+		return nil, nil, false, false
+	}
+	return (*gtk.TextIter)(unsafe.Pointer(&start)), (*gtk.TextIter)(unsafe.Pointer(&end)), gobool(wrapped), true
+}
+
+// Backward is a wrapper around gtk_source_search_context_backward2().
+// The returned bool reports whether a match was found.
+func (v *SourceSearchContext) Backward(iter *gtk.TextIter) (matchStart, matchEnd *gtk.TextIter, hasWrappedAround, ok bool) {
+	var start, end C.GtkTextIter
+	var wrapped C.gboolean
+	c := C.gtk_source_search_context_backward2(v.native(), nativeTextIter(iter),
+		&start, &end, &wrapped)
+	if !gobool(c) {
+		return nil, nil, false, false
+	}
+	return (*gtk.TextIter)(unsafe.Pointer(&start)), (*gtk.TextIter)(unsafe.Pointer(&end)), gobool(wrapped), true
+}
+
+// Replace is a wrapper around gtk_source_search_context_replace2().
+// On success, matchStart and matchEnd are revalidated to point to the
+// replaced text.
+func (v *SourceSearchContext) Replace(matchStart, matchEnd *gtk.TextIter, replace string) error {
+	cstr := C.CString(replace)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var err *C.GError
+	c := C.gtk_source_search_context_replace2(v.native(),
+		nativeTextIter(matchStart), nativeTextIter(matchEnd),
+		(*C.gchar)(cstr), C.gint(len(replace)), &err)
+	if !gobool(c) {
+		if err != nil {
+			return goError(err)
+		}
+		return errNotOccurrence
+	}
+	return nil
+}
+
+// ReplaceAll is a wrapper around gtk_source_search_context_replace_all().
+// It returns the number of replaced matches.
+func (v *SourceSearchContext) ReplaceAll(replace string) (uint, error) {
+	cstr := C.CString(replace)
+	defer C.free(unsafe.Pointer(cstr))
+
+	var err *C.GError
+	c := C.gtk_source_search_context_replace_all(v.native(), (*C.gchar)(cstr),
+		C.gint(len(replace)), &err)
+	if err != nil {
+		return 0, goError(err)
+	}
+	return uint(c), nil
+}
+
+// SourceSearchAsyncFunc is the callback of SourceSearchContext.ForwardAsync
+// and SourceSearchContext.BackwardAsync. It runs on the GTK main loop and
+// receives the same values as SourceSearchContext.Forward, plus any error.
+type SourceSearchAsyncFunc func(matchStart, matchEnd *gtk.TextIter, hasWrappedAround, ok bool, err error)
+
+// ForwardAsync is a wrapper around gtk_source_search_context_forward_async().
+// The search is cancelled when ctx is done, in which case callback receives
+// ctx.Err().
+func (v *SourceSearchContext) ForwardAsync(ctx context.Context, iter *gtk.TextIter, callback SourceSearchAsyncFunc) {
+	cancellable, release := cancellableNew(ctx)
+	data := registerAsyncReady(func(res *C.GAsyncResult) {
+		defer release()
+
+		var start, end C.GtkTextIter
+		var wrapped C.gboolean
+		var err *C.GError
+		c := C.gtk_source_search_context_forward_finish2(v.native(), res,
+			&start, &end, &wrapped, &err)
+		v.searchAsyncDone(ctx, callback, c, &start, &end, wrapped, err)
+	})
+	C._gtk_source_search_context_forward_async(v.native(), nativeTextIter(iter),
+		cancellable, data)
+}
+
+// BackwardAsync is a wrapper around gtk_source_search_context_backward_async().
+// The search is cancelled when ctx is done, in which case callback receives
+// ctx.Err().
+func (v *SourceSearchContext) BackwardAsync(ctx context.Context, iter *gtk.TextIter, callback SourceSearchAsyncFunc) {
+	cancellable, release := cancellableNew(ctx)
+	data := registerAsyncReady(func(res *C.GAsyncResult) {
+		defer release()
+
+		var start, end C.GtkTextIter
+		var wrapped C.gboolean
+		var err *C.GError
+		c := C.gtk_source_search_context_backward_finish2(v.native(), res,
+			&start, &end, &wrapped, &err)
+		v.searchAsyncDone(ctx, callback, c, &start, &end, wrapped, err)
+	})
+	C._gtk_source_search_context_backward_async(v.native(), nativeTextIter(iter),
+		cancellable, data)
+}
+
+// searchAsyncDone passes the outcome of an asynchronous search to callback.
+func (v *SourceSearchContext) searchAsyncDone(ctx context.Context, callback SourceSearchAsyncFunc,
+	found C.gboolean, start, end *C.GtkTextIter, wrapped C.gboolean, err *C.GError) {
+
+	if err != nil {
+		callback(nil, nil, false, false, asyncError(ctx, err))
+		return
+	}
+	if !gobool(found) {
+		callback(nil, nil, false, false, nil)
+		return
+	}
+	callback((*gtk.TextIter)(unsafe.Pointer(start)), (*gtk.TextIter)(unsafe.Pointer(end)),
+		gobool(wrapped), true, nil)
+}
@@ -0,0 +1,27 @@
+static GtkSourceSearchSettings *
+toGtkSourceSearchSettings(void *p)
+{
+	return (GTK_SOURCE_SEARCH_SETTINGS(p));
+}
+
+static GtkSourceSearchContext *
+toGtkSourceSearchContext(void *p)
+{
+	return (GTK_SOURCE_SEARCH_CONTEXT(p));
+}
+
+static inline void
+_gtk_source_search_context_forward_async(GtkSourceSearchContext *search,
+	const GtkTextIter *iter, GCancellable *cancellable, gpointer user_data)
+{
+	gtk_source_search_context_forward_async(search, iter, cancellable,
+		(GAsyncReadyCallback)(goAsyncReadyCallback), user_data);
+}
+
+static inline void
+_gtk_source_search_context_backward_async(GtkSourceSearchContext *search,
+	const GtkTextIter *iter, GCancellable *cancellable, gpointer user_data)
+{
+	gtk_source_search_context_backward_async(search, iter, cancellable,
+		(GAsyncReadyCallback)(goAsyncReadyCallback), user_data);
+}
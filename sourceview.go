@@ -12,20 +12,38 @@ package sourceview
 // #include <gtksourceview/gtksourcestyleschemechooserwidget.h>
 // #include <gtksourceview/gtksourcestyleschememanager.h>
 // #include <gtksourceview/gtksourceview.h>
+// #include <gtksourceview/gtksourceview-typebuiltins.h>
 // #include "sourceview.go.h"
 import "C"
 import (
+	"context"
 	"errors"
+	"sync"
 	"unsafe"
 
+	"github.com/gotk3/gotk3/gdk"
 	"github.com/gotk3/gotk3/glib"
 	"github.com/gotk3/gotk3/gtk"
 )
 
-var errNilPtr = errors.New("cgo returned unexpected nil pointer")
+var (
+	// ErrNoLanguage is returned by SourceLanguageManager.GuessLanguage when
+	// no language matches the file name or content type.
+	ErrNoLanguage = errors.New("no language matches")
+
+	errNilPtr          = errors.New("cgo returned unexpected nil pointer")
+	errNotSourceBuffer = errors.New("object is not a GtkSourceBuffer")
+	errNoGuessHint     = errors.New("filename and content type are both empty")
+)
 
 func init() {
 	tm := []glib.TypeMarshaler{
+		// Enums
+		{glib.Type(C.gtk_source_background_pattern_type_get_type()), marshalBackgroundPatternType},
+		{glib.Type(C.gtk_source_bracket_match_type_get_type()), marshalBracketMatchType},
+		{glib.Type(C.gtk_source_smart_home_end_type_get_type()), marshalSmartHomeEndType},
+
+		// Objects/Interfaces
 		{glib.Type(C.gtk_source_buffer_get_type()), marshalSourceBuffer},
 		{glib.Type(C.gtk_source_gutter_get_type()), marshalSourceGutter},
 		{glib.Type(C.gtk_source_language_get_type()), marshalSourceLanguage},
@@ -41,7 +59,7 @@ func init() {
 	glib.RegisterGValueMarshalers(tm)
 
 	gtk.WrapMap["GtkSourceView"] = wrapSourceView
-	gtk.WrapMap["GtkSourceBuffer"] = wrapSourceBuffer
+	gtk.WrapMap["GtkSourceBuffer"] = castSourceBuffer
 	gtk.WrapMap["GtkSourceGutter"] = wrapSourceGutter
 	gtk.WrapMap["GtkSourceLanguage"] = wrapSourceLanguage
 	gtk.WrapMap["GtkSourceLanguageManager"] = wrapSourceLanguageManager
@@ -60,10 +78,215 @@ func gbool(b bool) C.gboolean {
 	return C.gboolean(0)
 }
 
+func gobool(b C.gboolean) bool {
+	return b != C.FALSE
+}
+
 func goString(cstr *C.gchar) string {
 	return C.GoString((*C.char)(cstr))
 }
 
+// cstringOrNil returns a C copy of s, or nil if s is empty. The result must be
+// passed to C.free.
+func cstringOrNil(s string) *C.gchar {
+	if s == "" {
+		return nil
+	}
+	return (*C.gchar)(C.CString(s))
+}
+
+// goStringFree converts cstr to a Go string and frees it. It returns an empty
+// string if cstr is nil.
+func goStringFree(cstr *C.gchar) string {
+	if cstr == nil {
+		return ""
+	}
+	defer C.g_free(C.gpointer(cstr))
+	return goString(cstr)
+}
+
+// goStrings converts the NULL-terminated array cstrs to a slice of Go strings.
+// It returns nil if cstrs is nil.
+func goStrings(cstrs **C.gchar) []string {
+	if cstrs == nil {
+		return nil
+	}
+	var strs []string
+	for ; *cstrs != nil; cstrs = C.next_gcharptr(cstrs) {
+		strs = append(strs, goString(*cstrs))
+	}
+	return strs
+}
+
+// goStringsFree converts the NULL-terminated array cstrs to a slice of Go
+// strings and frees it with g_strfreev().
+func goStringsFree(cstrs **C.gchar) []string {
+	if cstrs == nil {
+		return nil
+	}
+	defer C.g_strfreev(cstrs)
+	return goStrings(cstrs)
+}
+
+// nativeTextIter returns iter as a pointer to the underlying GtkTextIter.
+func nativeTextIter(iter *gtk.TextIter) *C.GtkTextIter {
+	return (*C.GtkTextIter)(unsafe.Pointer(iter))
+}
+
+// nativePixbuf returns a pointer to the GdkPixbuf underlying pixbuf, or nil.
+func nativePixbuf(pixbuf *gdk.Pixbuf) *C.GdkPixbuf {
+	if pixbuf == nil || pixbuf.Object == nil {
+		return nil
+	}
+	return (*C.GdkPixbuf)(unsafe.Pointer(pixbuf.GObject))
+}
+
+// nativeGIcon returns a pointer to the GIcon underlying icon, or nil.
+func nativeGIcon(icon *glib.Object) *C.GIcon {
+	if icon == nil {
+		return nil
+	}
+	return (*C.GIcon)(unsafe.Pointer(icon.GObject))
+}
+
+// wrapEvent wraps event, which is only borrowed, as a gdk.Event.
+func wrapEvent(event *C.GdkEvent) *gdk.Event {
+	if event == nil {
+		return nil
+	}
+	// gdk.Event can only be built from a pointer by its GValue marshaler.
+	v, err := glib.ValueInit(glib.Type(C.gdk_event_get_type()))
+	if err != nil {
+		return nil
+	}
+	C.g_value_set_static_boxed((*C.GValue)(v.Native()), C.gconstpointer(unsafe.Pointer(event)))
+	e, err := v.GoValue()
+	if err != nil {
+		return nil
+	}
+	return e.(*gdk.Event)
+}
+
+// goError converts err to a Go error and frees it. It returns nil if err is
+// nil.
+func goError(err *C.GError) error {
+	if err == nil {
+		return nil
+	}
+	defer C.g_error_free(err)
+	return errors.New(goString(err.message))
+}
+
+/*
+ * Asynchronous operations
+ */
+
+var asyncReadyRegistry = struct {
+	sync.Mutex
+	next int
+	m    map[int]func(*C.GAsyncResult)
+}{
+	next: 1,
+	m:    make(map[int]func(*C.GAsyncResult)),
+}
+
+// registerAsyncReady stores fn until goAsyncReadyCallback runs it, and
+// returns the user data to pass along with goAsyncReadyCallback to a
+// GAsyncReadyCallback-taking C function.
+func registerAsyncReady(fn func(*C.GAsyncResult)) C.gpointer {
+	asyncReadyRegistry.Lock()
+	id := asyncReadyRegistry.next
+	asyncReadyRegistry.next++
+	asyncReadyRegistry.m[id] = fn
+	asyncReadyRegistry.Unlock()
+	return C.gpointer(uintptr(id))
+}
+
+// cancellableNew returns a GCancellable that is cancelled as soon as ctx is
+// done. The returned release func must be called once the operation using
+// the cancellable has completed.
+func cancellableNew(ctx context.Context) (*C.GCancellable, func()) {
+	c := C.g_cancellable_new()
+	done := make(chan struct{})
+	if ctx.Done() != nil {
+		// The watcher holds its own reference so that a late cancellation
+		// never races with release.
+		C.g_object_ref(C.gpointer(unsafe.Pointer(c)))
+		go func() {
+			defer C.g_object_unref(C.gpointer(unsafe.Pointer(c)))
+			select {
+			case <-ctx.Done():
+				C.g_cancellable_cancel(c)
+			case <-done:
+			}
+		}()
+	}
+	return c, func() {
+		close(done)
+		C.g_object_unref(C.gpointer(unsafe.Pointer(c)))
+	}
+}
+
+// asyncError converts err, the error of an operation run with a cancellable
+// from cancellableNew, to a Go error. It returns ctx.Err() instead if the
+// operation was cancelled because ctx was done.
+func asyncError(ctx context.Context, err *C.GError) error {
+	cancelled := err != nil &&
+		gobool(C.g_error_matches(err, C.g_io_error_quark(), C.G_IO_ERROR_CANCELLED))
+	goErr := goError(err)
+	if cancelled && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return goErr
+}
+
+/*
+ * Constants
+ */
+
+// BackgroundPatternType is a representation of GtkSourceBackgroundPatternType.
+type BackgroundPatternType int
+
+const (
+	BACKGROUND_PATTERN_TYPE_NONE BackgroundPatternType = C.GTK_SOURCE_BACKGROUND_PATTERN_TYPE_NONE
+	BACKGROUND_PATTERN_TYPE_GRID BackgroundPatternType = C.GTK_SOURCE_BACKGROUND_PATTERN_TYPE_GRID
+)
+
+func marshalBackgroundPatternType(p uintptr) (interface{}, error) {
+	c := C.g_value_get_enum((*C.GValue)(unsafe.Pointer(p)))
+	return BackgroundPatternType(c), nil
+}
+
+// BracketMatchType is a representation of GtkSourceBracketMatchType.
+type BracketMatchType int
+
+const (
+	BRACKET_MATCH_NONE         BracketMatchType = C.GTK_SOURCE_BRACKET_MATCH_NONE
+	BRACKET_MATCH_OUT_OF_RANGE BracketMatchType = C.GTK_SOURCE_BRACKET_MATCH_OUT_OF_RANGE
+	BRACKET_MATCH_NOT_FOUND    BracketMatchType = C.GTK_SOURCE_BRACKET_MATCH_NOT_FOUND
+	BRACKET_MATCH_FOUND        BracketMatchType = C.GTK_SOURCE_BRACKET_MATCH_FOUND
+)
+
+func marshalBracketMatchType(p uintptr) (interface{}, error) {
+	c := C.g_value_get_enum((*C.GValue)(unsafe.Pointer(p)))
+	return BracketMatchType(c), nil
+}
+
+// SmartHomeEndType is a representation of GtkSourceSmartHomeEndType.
+type SmartHomeEndType int
+
+const (
+	SMART_HOME_END_DISABLED SmartHomeEndType = C.GTK_SOURCE_SMART_HOME_END_DISABLED
+	SMART_HOME_END_BEFORE   SmartHomeEndType = C.GTK_SOURCE_SMART_HOME_END_BEFORE
+	SMART_HOME_END_AFTER    SmartHomeEndType = C.GTK_SOURCE_SMART_HOME_END_AFTER
+	SMART_HOME_END_ALWAYS   SmartHomeEndType = C.GTK_SOURCE_SMART_HOME_END_ALWAYS
+)
+
+func marshalSmartHomeEndType(p uintptr) (interface{}, error) {
+	c := C.g_value_get_enum((*C.GValue)(unsafe.Pointer(p)))
+	return SmartHomeEndType(c), nil
+}
+
 /*
  * GtkSourceGutter
  */
@@ -92,6 +315,47 @@ func wrapSourceGutter(obj *glib.Object) *SourceGutter {
 	return &SourceGutter{obj}
 }
 
+// GetWindow is a wrapper around gtk_source_gutter_get_window().
+func (v *SourceGutter) GetWindow() (*gdk.Window, error) {
+	c := C.gtk_source_gutter_get_window(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return &gdk.Window{glib.Take(unsafe.Pointer(c))}, nil
+}
+
+// Insert is a wrapper around gtk_source_gutter_insert().
+// Renderers are ordered by ascending position. It returns false if renderer
+// could not be added.
+func (v *SourceGutter) Insert(renderer ISourceGutterRenderer, position int) bool {
+	return gobool(C.gtk_source_gutter_insert(v.native(), renderer.toSourceGutterRenderer(), C.gint(position)))
+}
+
+// Reorder is a wrapper around gtk_source_gutter_reorder().
+func (v *SourceGutter) Reorder(renderer ISourceGutterRenderer, position int) {
+	C.gtk_source_gutter_reorder(v.native(), renderer.toSourceGutterRenderer(), C.gint(position))
+}
+
+// Remove is a wrapper around gtk_source_gutter_remove().
+func (v *SourceGutter) Remove(renderer ISourceGutterRenderer) {
+	C.gtk_source_gutter_remove(v.native(), renderer.toSourceGutterRenderer())
+}
+
+// GetRendererAtPos is a wrapper around gtk_source_gutter_get_renderer_at_pos().
+// It returns nil if there is no renderer at the given position.
+func (v *SourceGutter) GetRendererAtPos(x, y int) *SourceGutterRenderer {
+	c := C.gtk_source_gutter_get_renderer_at_pos(v.native(), C.gint(x), C.gint(y))
+	if c == nil {
+		return nil
+	}
+	return wrapSourceGutterRenderer(glib.Take(unsafe.Pointer(c)))
+}
+
+// QueueDraw is a wrapper around gtk_source_gutter_queue_draw().
+func (v *SourceGutter) QueueDraw() {
+	C.gtk_source_gutter_queue_draw(v.native())
+}
+
 /*
  * GtkSourceView
  */
@@ -101,19 +365,134 @@ type SourceView struct {
 	gtk.TextView
 }
 
+// GetAutoIndent is a wrapper around gtk_source_view_get_auto_indent().
+func (v *SourceView) GetAutoIndent() bool {
+	return gobool(C.gtk_source_view_get_auto_indent(v.native()))
+}
+
+// SetAutoIndent is a wrapper around gtk_source_view_set_auto_indent().
+func (v *SourceView) SetAutoIndent(enable bool) {
+	C.gtk_source_view_set_auto_indent(v.native(), gbool(enable))
+}
+
+// GetBackgroundPattern is a wrapper around gtk_source_view_get_background_pattern().
+func (v *SourceView) GetBackgroundPattern() BackgroundPatternType {
+	return BackgroundPatternType(C.gtk_source_view_get_background_pattern(v.native()))
+}
+
+// SetBackgroundPattern is a wrapper around gtk_source_view_set_background_pattern().
+func (v *SourceView) SetBackgroundPattern(pattern BackgroundPatternType) {
+	C.gtk_source_view_set_background_pattern(v.native(), C.GtkSourceBackgroundPatternType(pattern))
+}
+
+// GetHighlightCurrentLine is a wrapper around gtk_source_view_get_highlight_current_line().
+func (v *SourceView) GetHighlightCurrentLine() bool {
+	return gobool(C.gtk_source_view_get_highlight_current_line(v.native()))
+}
+
 // SetHighlightCurrentLine is a wrapper around gtk_source_view_set_highlight_current_line().
 func (v *SourceView) SetHighlightCurrentLine(highlight bool) {
 	C.gtk_source_view_set_highlight_current_line(v.native(), gbool(highlight))
 }
 
+// GetIndentOnTab is a wrapper around gtk_source_view_get_indent_on_tab().
+func (v *SourceView) GetIndentOnTab() bool {
+	return gobool(C.gtk_source_view_get_indent_on_tab(v.native()))
+}
+
+// SetIndentOnTab is a wrapper around gtk_source_view_set_indent_on_tab().
+func (v *SourceView) SetIndentOnTab(enable bool) {
+	C.gtk_source_view_set_indent_on_tab(v.native(), gbool(enable))
+}
+
+// GetIndentWidth is a wrapper around gtk_source_view_get_indent_width().
+func (v *SourceView) GetIndentWidth() int {
+	return int(C.gtk_source_view_get_indent_width(v.native()))
+}
+
+// SetIndentWidth is a wrapper around gtk_source_view_set_indent_width().
+func (v *SourceView) SetIndentWidth(width int) {
+	C.gtk_source_view_set_indent_width(v.native(), C.gint(width))
+}
+
+// GetInsertSpacesInsteadOfTabs is a wrapper around gtk_source_view_get_insert_spaces_instead_of_tabs().
+func (v *SourceView) GetInsertSpacesInsteadOfTabs() bool {
+	return gobool(C.gtk_source_view_get_insert_spaces_instead_of_tabs(v.native()))
+}
+
+// SetInsertSpacesInsteadOfTabs is a wrapper around gtk_source_view_set_insert_spaces_instead_of_tabs().
+func (v *SourceView) SetInsertSpacesInsteadOfTabs(enable bool) {
+	C.gtk_source_view_set_insert_spaces_instead_of_tabs(v.native(), gbool(enable))
+}
+
+// GetRightMarginPosition is a wrapper around gtk_source_view_get_right_margin_position().
+func (v *SourceView) GetRightMarginPosition() uint {
+	return uint(C.gtk_source_view_get_right_margin_position(v.native()))
+}
+
+// SetRightMarginPosition is a wrapper around gtk_source_view_set_right_margin_position().
+func (v *SourceView) SetRightMarginPosition(pos uint) {
+	C.gtk_source_view_set_right_margin_position(v.native(), C.guint(pos))
+}
+
+// GetShowLineMarks is a wrapper around gtk_source_view_get_show_line_marks().
+func (v *SourceView) GetShowLineMarks() bool {
+	return gobool(C.gtk_source_view_get_show_line_marks(v.native()))
+}
+
+// SetShowLineMarks is a wrapper around gtk_source_view_set_show_line_marks().
+func (v *SourceView) SetShowLineMarks(show bool) {
+	C.gtk_source_view_set_show_line_marks(v.native(), gbool(show))
+}
+
+// GetShowLineNumbers is a wrapper around gtk_source_view_get_show_line_numbers().
+func (v *SourceView) GetShowLineNumbers() bool {
+	return gobool(C.gtk_source_view_get_show_line_numbers(v.native()))
+}
+
 // SetShowLineNumbers is a wrapper around gtk_source_view_set_show_line_numbers().
 func (v *SourceView) SetShowLineNumbers(show bool) {
 	C.gtk_source_view_set_show_line_numbers(v.native(), gbool(show))
 }
 
-// SetShowRightMargin is a wrapper around gtk_source_view_get_show_right_margin().
-func (v *SourceView) SetShowRightMargin() {
-	C.gtk_source_view_get_show_right_margin(v.native())
+// GetShowRightMargin is a wrapper around gtk_source_view_get_show_right_margin().
+func (v *SourceView) GetShowRightMargin() bool {
+	return gobool(C.gtk_source_view_get_show_right_margin(v.native()))
+}
+
+// SetShowRightMargin is a wrapper around gtk_source_view_set_show_right_margin().
+func (v *SourceView) SetShowRightMargin(show bool) {
+	C.gtk_source_view_set_show_right_margin(v.native(), gbool(show))
+}
+
+// GetSmartBackspace is a wrapper around gtk_source_view_get_smart_backspace().
+func (v *SourceView) GetSmartBackspace() bool {
+	return gobool(C.gtk_source_view_get_smart_backspace(v.native()))
+}
+
+// SetSmartBackspace is a wrapper around gtk_source_view_set_smart_backspace().
+func (v *SourceView) SetSmartBackspace(enable bool) {
+	C.gtk_source_view_set_smart_backspace(v.native(), gbool(enable))
+}
+
+// GetSmartHomeEnd is a wrapper around gtk_source_view_get_smart_home_end().
+func (v *SourceView) GetSmartHomeEnd() SmartHomeEndType {
+	return SmartHomeEndType(C.gtk_source_view_get_smart_home_end(v.native()))
+}
+
+// SetSmartHomeEnd is a wrapper around gtk_source_view_set_smart_home_end().
+func (v *SourceView) SetSmartHomeEnd(smartHomeEnd SmartHomeEndType) {
+	C.gtk_source_view_set_smart_home_end(v.native(), C.GtkSourceSmartHomeEndType(smartHomeEnd))
+}
+
+// GetTabWidth is a wrapper around gtk_source_view_get_tab_width().
+func (v *SourceView) GetTabWidth() uint {
+	return uint(C.gtk_source_view_get_tab_width(v.native()))
+}
+
+// SetTabWidth is a wrapper around gtk_source_view_set_tab_width().
+func (v *SourceView) SetTabWidth(width uint) {
+	C.gtk_source_view_set_tab_width(v.native(), C.guint(width))
 }
 
 // native returns a pointer to the underlying GtkSourceView.
@@ -167,7 +546,7 @@ func (v *SourceView) GetBuffer() (*SourceBuffer, error) {
 	if c == nil {
 		return nil, errNilPtr
 	}
-	return wrapSourceBuffer(glib.Take(unsafe.Pointer(c))), nil
+	return wrapSourceBuffer(glib.Take(unsafe.Pointer(c)))
 }
 
 // GetGutter is a wrapper around gtk_source_view_get_gutter().
@@ -179,6 +558,31 @@ func (v *SourceView) GetGutter(wt gtk.TextWindowType) (*SourceGutter, error) {
 	return wrapSourceGutter(glib.Take(unsafe.Pointer(c))), nil
 }
 
+// ConnectLineMarkActivated connects f to the "line-mark-activated" signal,
+// emitted when the line mark gutter is clicked. iter is at the start of the
+// activated line; both arguments are only valid during the call to f.
+func (v *SourceView) ConnectLineMarkActivated(f func(iter *gtk.TextIter, event *gdk.Event)) (glib.SignalHandle, error) {
+	return v.Connect("line-mark-activated", func(_ *SourceView, iter *gtk.TextIter, event *gdk.Event) {
+		f(iter, event)
+	})
+}
+
+// MoveToMatchingBracket emits the "move-to-matching-bracket" keybinding
+// signal, moving the cursor to the bracket matching the one next to it. If
+// extendSelection is true the selection is extended to the matching bracket.
+func (v *SourceView) MoveToMatchingBracket(extendSelection bool) error {
+	_, err := v.Emit("move-to-matching-bracket", extendSelection)
+	return err
+}
+
+// ConnectMoveToMatchingBracket connects f to the "move-to-matching-bracket"
+// keybinding signal, bound to Ctrl+% by default.
+func (v *SourceView) ConnectMoveToMatchingBracket(f func(extendSelection bool)) (glib.SignalHandle, error) {
+	return v.Connect("move-to-matching-bracket", func(_ *SourceView, extendSelection bool) {
+		f(extendSelection)
+	})
+}
+
 /*
  * GtkSourceBuffer
  */
@@ -208,23 +612,49 @@ func (v *SourceBuffer) asTextBuffer() *C.GtkTextBuffer {
 
 func marshalSourceBuffer(p uintptr) (interface{}, error) {
 	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	if c == nil {
+		return (*SourceBuffer)(nil), nil
+	}
 	obj := glib.Take(unsafe.Pointer(c))
-	return wrapSourceBuffer(obj), nil
+	return wrapSourceBuffer(obj)
+}
+
+// wrapSourceBuffer wraps obj as a SourceBuffer, returning errNilPtr if obj is
+// nil and errNotSourceBuffer if obj is not a GtkSourceBuffer.
+func wrapSourceBuffer(obj *glib.Object) (*SourceBuffer, error) {
+	if obj == nil || obj.GObject == nil {
+		return nil, errNilPtr
+	}
+	if !obj.IsA(glib.Type(C.gtk_source_buffer_get_type())) {
+		return nil, errNotSourceBuffer
+	}
+	return castSourceBuffer(obj), nil
 }
 
-func wrapSourceBuffer(obj *glib.Object) *SourceBuffer {
+// castSourceBuffer wraps obj as a SourceBuffer without checking its type. It
+// is registered in gtk.WrapMap, which only dispatches on the GtkSourceBuffer
+// class name and requires a single return value.
+func castSourceBuffer(obj *glib.Object) *SourceBuffer {
 	return &SourceBuffer{gtk.TextBuffer{obj}}
 }
 
-// SourceBufferNew is a wrapper around gtk_text_buffer_new().
+// SourceBufferNew is a wrapper around gtk_source_buffer_new().
 func SourceBufferNew() (*SourceBuffer, error) {
-	c := C.gtk_text_buffer_new(nil)
+	return SourceBufferNewWithTagTable(nil)
+}
+
+// SourceBufferNewWithTagTable is a wrapper around gtk_source_buffer_new().
+// A nil table makes the buffer create a new tag table of its own.
+func SourceBufferNewWithTagTable(table *gtk.TextTagTable) (*SourceBuffer, error) {
+	var ctable *C.GtkTextTagTable
+	if table != nil {
+		ctable = C.toGtkTextTagTable(unsafe.Pointer(table.GObject))
+	}
+	c := C.gtk_source_buffer_new(ctable)
 	if c == nil {
 		return nil, errNilPtr
 	}
-
-	e := wrapSourceBuffer(glib.Take(unsafe.Pointer(c)))
-	return e, nil
+	return wrapSourceBuffer(glib.Take(unsafe.Pointer(c)))
 }
 
 // SourceBufferNewWithLanguage is a wrapper around gtk_source_buffer_new_with_language().
@@ -233,9 +663,7 @@ func SourceBufferNewWithLanguage(l *SourceLanguage) (*SourceBuffer, error) {
 	if c == nil {
 		return nil, errNilPtr
 	}
-
-	e := wrapSourceBuffer(glib.Take(unsafe.Pointer(c)))
-	return e, nil
+	return wrapSourceBuffer(glib.Take(unsafe.Pointer(c)))
 }
 
 // SetText is a wrapper around gtk_text_buffer_set_text().
@@ -262,8 +690,9 @@ func (v *SourceBuffer) EndNotUndoableAction() {
 }
 
 // GetMaxUndoLevels is a wrapper around gtk_source_buffer_get_max_undo_levels().
-func (v *SourceBuffer) GetMaxUndoLevels() {
-	C.gtk_source_buffer_get_max_undo_levels(v.native())
+// A negative value means no limit.
+func (v *SourceBuffer) GetMaxUndoLevels() int {
+	return int(C.gtk_source_buffer_get_max_undo_levels(v.native()))
 }
 
 // SetMaxUndoLevels is a wrapper around gtk_source_buffer_set_max_undo_levels().
@@ -271,6 +700,101 @@ func (v *SourceBuffer) SetMaxUndoLevels(levels int) {
 	C.gtk_source_buffer_set_max_undo_levels(v.native(), C.gint(levels))
 }
 
+// CanUndo is a wrapper around gtk_source_buffer_can_undo().
+func (v *SourceBuffer) CanUndo() bool {
+	return gobool(C.gtk_source_buffer_can_undo(v.native()))
+}
+
+// CanRedo is a wrapper around gtk_source_buffer_can_redo().
+func (v *SourceBuffer) CanRedo() bool {
+	return gobool(C.gtk_source_buffer_can_redo(v.native()))
+}
+
+// Undo is a wrapper around gtk_source_buffer_undo().
+func (v *SourceBuffer) Undo() {
+	C.gtk_source_buffer_undo(v.native())
+}
+
+// Redo is a wrapper around gtk_source_buffer_redo().
+func (v *SourceBuffer) Redo() {
+	C.gtk_source_buffer_redo(v.native())
+}
+
+// ConnectUndo connects f to the "undo" signal, emitted when an undo is
+// performed.
+func (v *SourceBuffer) ConnectUndo(f func()) (glib.SignalHandle, error) {
+	return v.Connect("undo", f)
+}
+
+// ConnectRedo connects f to the "redo" signal, emitted when a redo is
+// performed.
+func (v *SourceBuffer) ConnectRedo(f func()) (glib.SignalHandle, error) {
+	return v.Connect("redo", f)
+}
+
+// ConnectCanUndoNotify connects f to change notifications of the "can-undo"
+// property. f receives the new value.
+func (v *SourceBuffer) ConnectCanUndoNotify(f func(canUndo bool)) (glib.SignalHandle, error) {
+	return v.Connect("notify::can-undo", func(buffer *SourceBuffer) {
+		f(buffer.CanUndo())
+	})
+}
+
+// ConnectCanRedoNotify connects f to change notifications of the "can-redo"
+// property. f receives the new value.
+func (v *SourceBuffer) ConnectCanRedoNotify(f func(canRedo bool)) (glib.SignalHandle, error) {
+	return v.Connect("notify::can-redo", func(buffer *SourceBuffer) {
+		f(buffer.CanRedo())
+	})
+}
+
+// GetHighlightSyntax is a wrapper around gtk_source_buffer_get_highlight_syntax().
+func (v *SourceBuffer) GetHighlightSyntax() bool {
+	return gobool(C.gtk_source_buffer_get_highlight_syntax(v.native()))
+}
+
+// SetHighlightSyntax is a wrapper around gtk_source_buffer_set_highlight_syntax().
+func (v *SourceBuffer) SetHighlightSyntax(highlight bool) {
+	C.gtk_source_buffer_set_highlight_syntax(v.native(), gbool(highlight))
+}
+
+// EnsureHighlight is a wrapper around gtk_source_buffer_ensure_highlight().
+// It forces the region between start and end to be highlighted
+// synchronously.
+func (v *SourceBuffer) EnsureHighlight(start, end *gtk.TextIter) {
+	C.gtk_source_buffer_ensure_highlight(v.native(), nativeTextIter(start),
+		nativeTextIter(end))
+}
+
+// ConnectHighlightUpdated connects f to the "highlight-updated" signal,
+// emitted when the syntax highlighting of the region between start and end
+// has been updated. Both iterators are only valid during the call to f.
+func (v *SourceBuffer) ConnectHighlightUpdated(f func(start, end *gtk.TextIter)) (glib.SignalHandle, error) {
+	return v.Connect("highlight-updated", func(_ *SourceBuffer, start, end *gtk.TextIter) {
+		f(start, end)
+	})
+}
+
+// GetHighlightMatchingBrackets is a wrapper around gtk_source_buffer_get_highlight_matching_brackets().
+func (v *SourceBuffer) GetHighlightMatchingBrackets() bool {
+	return gobool(C.gtk_source_buffer_get_highlight_matching_brackets(v.native()))
+}
+
+// SetHighlightMatchingBrackets is a wrapper around gtk_source_buffer_set_highlight_matching_brackets().
+func (v *SourceBuffer) SetHighlightMatchingBrackets(highlight bool) {
+	C.gtk_source_buffer_set_highlight_matching_brackets(v.native(), gbool(highlight))
+}
+
+// ConnectBracketMatched connects f to the "bracket-matched" signal, emitted
+// when the cursor moves next to a bracket. iter is set to the matching
+// bracket only when state is BRACKET_MATCH_FOUND, and is only valid during
+// the call to f.
+func (v *SourceBuffer) ConnectBracketMatched(f func(iter *gtk.TextIter, state BracketMatchType)) (glib.SignalHandle, error) {
+	return v.Connect("bracket-matched", func(_ *SourceBuffer, iter *gtk.TextIter, state BracketMatchType) {
+		f(iter, state)
+	})
+}
+
 // SetStyleScheme is a wrapper around gtk_source_buffer_set_style_scheme().
 func (v *SourceBuffer) SetStyleScheme(scheme *SourceStyleScheme) {
 	C.gtk_source_buffer_set_style_scheme(v.native(), scheme.native())
@@ -337,6 +861,56 @@ func (v *SourceLanguageManager) GetLanguage(id string) (*SourceLanguage, error)
 	return wrapSourceLanguage(glib.Take(unsafe.Pointer(c))), nil
 }
 
+// GuessLanguage is a wrapper around gtk_source_language_manager_guess_language().
+// Either filename or contentType may be empty, but not both. ErrNoLanguage
+// is returned if no language matches.
+func (v *SourceLanguageManager) GuessLanguage(filename, contentType string) (*SourceLanguage, error) {
+	if filename == "" && contentType == "" {
+		return nil, errNoGuessHint
+	}
+	cfilename := cstringOrNil(filename)
+	defer C.free(unsafe.Pointer(cfilename))
+	ccontentType := cstringOrNil(contentType)
+	defer C.free(unsafe.Pointer(ccontentType))
+	c := C.gtk_source_language_manager_guess_language(v.native(), cfilename,
+		ccontentType)
+	if c == nil {
+		return nil, ErrNoLanguage
+	}
+	return wrapSourceLanguage(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// GetLanguageIDs is a wrapper around gtk_source_language_manager_get_language_ids().
+func (v *SourceLanguageManager) GetLanguageIDs() []string {
+	return goStrings(C.gtk_source_language_manager_get_language_ids(v.native()))
+}
+
+// SetSearchPath is a wrapper around gtk_source_language_manager_set_search_path().
+// A nil paths resets the search path to the default. The search path cannot
+// be changed once languages have been loaded.
+func (v *SourceLanguageManager) SetSearchPath(paths []string) {
+	if paths == nil {
+		C.gtk_source_language_manager_set_search_path(v.native(), nil)
+		return
+	}
+
+	cpaths := C.make_strings(C.int(len(paths) + 1))
+	for i, path := range paths {
+		cstr := C.CString(path)
+		defer C.free(unsafe.Pointer(cstr))
+		C.set_string(cpaths, C.int(i), (*C.gchar)(cstr))
+	}
+
+	C.set_string(cpaths, C.int(len(paths)), nil)
+	C.gtk_source_language_manager_set_search_path(v.native(), cpaths)
+	C.destroy_strings(cpaths)
+}
+
+// GetSearchPath is a wrapper around gtk_source_language_manager_get_search_path().
+func (v *SourceLanguageManager) GetSearchPath() []string {
+	return goStrings(C.gtk_source_language_manager_get_search_path(v.native()))
+}
+
 /*
  * GtkSourceLanguage
  */
@@ -346,7 +920,7 @@ type SourceLanguage struct {
 	*glib.Object
 }
 
-// native returns a pointer to the underlying GtkSourceLanguageManager.
+// native returns a pointer to the underlying GtkSourceLanguage.
 func (v *SourceLanguage) native() *C.GtkSourceLanguage {
 	if v == nil || v.GObject == nil {
 		return nil
@@ -365,6 +939,68 @@ func wrapSourceLanguage(obj *glib.Object) *SourceLanguage {
 	return &SourceLanguage{obj}
 }
 
+// GetID is a wrapper around gtk_source_language_get_id().
+func (v *SourceLanguage) GetID() string {
+	return goString(C.gtk_source_language_get_id(v.native()))
+}
+
+// GetName is a wrapper around gtk_source_language_get_name().
+func (v *SourceLanguage) GetName() string {
+	return goString(C.gtk_source_language_get_name(v.native()))
+}
+
+// GetSection is a wrapper around gtk_source_language_get_section().
+func (v *SourceLanguage) GetSection() string {
+	return goString(C.gtk_source_language_get_section(v.native()))
+}
+
+// GetHidden is a wrapper around gtk_source_language_get_hidden().
+func (v *SourceLanguage) GetHidden() bool {
+	return gobool(C.gtk_source_language_get_hidden(v.native()))
+}
+
+// GetMetadata is a wrapper around gtk_source_language_get_metadata(). It
+// returns an empty string if the language has no metadata called name.
+func (v *SourceLanguage) GetMetadata(name string) string {
+	cstr := C.CString(name)
+	defer C.free(unsafe.Pointer(cstr))
+	return goString(C.gtk_source_language_get_metadata(v.native(),
+		(*C.gchar)(cstr)))
+}
+
+// GetMimeTypes is a wrapper around gtk_source_language_get_mime_types().
+func (v *SourceLanguage) GetMimeTypes() []string {
+	return goStringsFree(C.gtk_source_language_get_mime_types(v.native()))
+}
+
+// GetGlobs is a wrapper around gtk_source_language_get_globs().
+func (v *SourceLanguage) GetGlobs() []string {
+	return goStringsFree(C.gtk_source_language_get_globs(v.native()))
+}
+
+// GetStyleIDs is a wrapper around gtk_source_language_get_style_ids().
+func (v *SourceLanguage) GetStyleIDs() []string {
+	return goStringsFree(C.gtk_source_language_get_style_ids(v.native()))
+}
+
+// GetStyleName is a wrapper around gtk_source_language_get_style_name().
+// It returns an empty string if the language defines no style styleID.
+func (v *SourceLanguage) GetStyleName(styleID string) string {
+	cstr := C.CString(styleID)
+	defer C.free(unsafe.Pointer(cstr))
+	return goString(C.gtk_source_language_get_style_name(v.native(),
+		(*C.gchar)(cstr)))
+}
+
+// GetStyleFallback is a wrapper around gtk_source_language_get_style_fallback().
+// It returns an empty string if styleID has no fallback.
+func (v *SourceLanguage) GetStyleFallback(styleID string) string {
+	cstr := C.CString(styleID)
+	defer C.free(unsafe.Pointer(cstr))
+	return goString(C.gtk_source_language_get_style_fallback(v.native(),
+		(*C.gchar)(cstr)))
+}
+
 /*
  * GtkSourceStyle
  */
@@ -101,3 +101,28 @@ toGtkTextTag(void *p)
 {
 	return (GTK_TEXT_TAG(p));
 }
+
+static GtkTextTagTable *
+toGtkTextTagTable(void *p)
+{
+	return (GTK_TEXT_TAG_TABLE(p));
+}
+
+extern void goAsyncReadyCallback(GObject *source_object, GAsyncResult *res, gpointer user_data);
+
+static inline guint
+_g_object_get_uint(gpointer object, const gchar *property_name)
+{
+	guint value = 0;
+	g_object_get(object, property_name, &value, NULL);
+	return value;
+}
+
+static inline void
+_g_object_set_uint(gpointer object, const gchar *property_name, guint value)
+{
+	g_object_set(object, property_name, value, NULL);
+}
+
+extern void goFileProgressCallback(goffset current_num_bytes, goffset total_num_bytes, gpointer user_data);
+extern void goFileProgressDestroyNotify(gpointer user_data);
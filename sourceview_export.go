@@ -0,0 +1,209 @@
+package sourceview
+
+// #cgo pkg-config: gtksourceview-3.0
+// #include <stdlib.h>
+// #include <gio/gio.h>
+// #include <gtksourceview/gtksourcecompletioncontext.h>
+// #include <gtksourceview/gtksourcecompletionproposal.h>
+// #include <gtksourceview/gtksourcecompletionprovider.h>
+// #include <gtksourceview/gtksourcegutterrenderer.h>
+// #include <gtksourceview/gtksourceundomanager.h>
+import "C"
+import (
+	"unsafe"
+
+	"github.com/gotk3/gotk3/cairo"
+	"github.com/gotk3/gotk3/gdk"
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+//export goAsyncReadyCallback
+func goAsyncReadyCallback(source *C.GObject, res *C.GAsyncResult, data C.gpointer) {
+	id := int(uintptr(data))
+
+	asyncReadyRegistry.Lock()
+	fn := asyncReadyRegistry.m[id]
+	delete(asyncReadyRegistry.m, id)
+	asyncReadyRegistry.Unlock()
+
+	fn(res)
+}
+
+//export goFileProgressCallback
+func goFileProgressCallback(current, total C.goffset, data C.gpointer) {
+	id := int(uintptr(data))
+
+	fileProgressRegistry.Lock()
+	fn := fileProgressRegistry.m[id]
+	fileProgressRegistry.Unlock()
+
+	if fn != nil {
+		fn(int64(current), int64(total))
+	}
+}
+
+//export goFileProgressDestroyNotify
+func goFileProgressDestroyNotify(data C.gpointer) {
+	id := int(uintptr(data))
+
+	fileProgressRegistry.Lock()
+	delete(fileProgressRegistry.m, id)
+	fileProgressRegistry.Unlock()
+}
+
+/*
+ * GoSourceCompletionProvider
+ */
+
+//export goCompletionProviderGetName
+func goCompletionProviderGetName(handle C.gint) *C.gchar {
+	// The caller frees the name with g_free(), so it must not come from
+	// C.CString's malloc.
+	cstr := C.CString(lookupCompletionProvider(handle).GetName())
+	defer C.free(unsafe.Pointer(cstr))
+	return C.g_strdup((*C.gchar)(cstr))
+}
+
+//export goCompletionProviderGetIcon
+func goCompletionProviderGetIcon(handle C.gint) *C.GdkPixbuf {
+	return nativePixbuf(lookupCompletionProvider(handle).GetIcon())
+}
+
+//export goCompletionProviderPopulate
+func goCompletionProviderPopulate(handle C.gint, context *C.GtkSourceCompletionContext) {
+	ctx := wrapSourceCompletionContext(glib.Take(unsafe.Pointer(context)))
+	lookupCompletionProvider(handle).Populate(ctx)
+}
+
+//export goCompletionProviderGetActivation
+func goCompletionProviderGetActivation(handle C.gint) C.GtkSourceCompletionActivation {
+	return C.GtkSourceCompletionActivation(lookupCompletionProvider(handle).GetActivation())
+}
+
+//export goCompletionProviderActivateProposal
+func goCompletionProviderActivateProposal(handle C.gint, proposal *C.GtkSourceCompletionProposal, iter *C.GtkTextIter) C.gboolean {
+	p := wrapSourceCompletionProposal(glib.Take(unsafe.Pointer(proposal)))
+	activated := lookupCompletionProvider(handle).ActivateProposal(p, (*gtk.TextIter)(unsafe.Pointer(iter)))
+	return gbool(activated)
+}
+
+//export goCompletionProviderGetInteractiveDelay
+func goCompletionProviderGetInteractiveDelay(handle C.gint) C.gint {
+	return C.gint(lookupCompletionProvider(handle).GetInteractiveDelay())
+}
+
+//export goCompletionProviderGetPriority
+func goCompletionProviderGetPriority(handle C.gint) C.gint {
+	return C.gint(lookupCompletionProvider(handle).GetPriority())
+}
+
+//export goCompletionProviderFinalize
+func goCompletionProviderFinalize(handle C.gint) {
+	completionProviderRegistry.Lock()
+	delete(completionProviderRegistry.m, int(handle))
+	completionProviderRegistry.Unlock()
+}
+
+/*
+ * GoSourceGutterRenderer
+ */
+
+//export goGutterRendererBegin
+func goGutterRendererBegin(handle C.gint, cr *C.cairo_t, backgroundArea, cellArea *C.GdkRectangle, start, end *C.GtkTextIter) {
+	lookupGutterRenderer(handle).Begin(
+		cairo.WrapContext(uintptr(unsafe.Pointer(cr))),
+		gdk.WrapRectangle(uintptr(unsafe.Pointer(backgroundArea))),
+		gdk.WrapRectangle(uintptr(unsafe.Pointer(cellArea))),
+		(*gtk.TextIter)(unsafe.Pointer(start)),
+		(*gtk.TextIter)(unsafe.Pointer(end)))
+}
+
+//export goGutterRendererDraw
+func goGutterRendererDraw(handle C.gint, cr *C.cairo_t, backgroundArea, cellArea *C.GdkRectangle, start, end *C.GtkTextIter, state C.GtkSourceGutterRendererState) {
+	lookupGutterRenderer(handle).Draw(
+		cairo.WrapContext(uintptr(unsafe.Pointer(cr))),
+		gdk.WrapRectangle(uintptr(unsafe.Pointer(backgroundArea))),
+		gdk.WrapRectangle(uintptr(unsafe.Pointer(cellArea))),
+		(*gtk.TextIter)(unsafe.Pointer(start)),
+		(*gtk.TextIter)(unsafe.Pointer(end)),
+		SourceGutterRendererState(state))
+}
+
+//export goGutterRendererEnd
+func goGutterRendererEnd(handle C.gint) {
+	lookupGutterRenderer(handle).End()
+}
+
+//export goGutterRendererQueryData
+func goGutterRendererQueryData(handle C.gint, start, end *C.GtkTextIter, state C.GtkSourceGutterRendererState) {
+	lookupGutterRenderer(handle).QueryData(
+		(*gtk.TextIter)(unsafe.Pointer(start)),
+		(*gtk.TextIter)(unsafe.Pointer(end)),
+		SourceGutterRendererState(state))
+}
+
+//export goGutterRendererQueryActivatable
+func goGutterRendererQueryActivatable(handle C.gint, iter *C.GtkTextIter, area *C.GdkRectangle, event *C.GdkEvent) C.gboolean {
+	activatable := lookupGutterRenderer(handle).QueryActivatable(
+		(*gtk.TextIter)(unsafe.Pointer(iter)),
+		gdk.WrapRectangle(uintptr(unsafe.Pointer(area))),
+		wrapEvent(event))
+	return gbool(activatable)
+}
+
+//export goGutterRendererActivate
+func goGutterRendererActivate(handle C.gint, iter *C.GtkTextIter, area *C.GdkRectangle, event *C.GdkEvent) {
+	lookupGutterRenderer(handle).Activate(
+		(*gtk.TextIter)(unsafe.Pointer(iter)),
+		gdk.WrapRectangle(uintptr(unsafe.Pointer(area))),
+		wrapEvent(event))
+}
+
+//export goGutterRendererFinalize
+func goGutterRendererFinalize(handle C.gint) {
+	gutterRendererRegistry.Lock()
+	delete(gutterRendererRegistry.m, int(handle))
+	gutterRendererRegistry.Unlock()
+}
+
+/*
+ * GoSourceUndoManager
+ */
+
+//export goUndoManagerCanUndo
+func goUndoManagerCanUndo(handle C.gint) C.gboolean {
+	return gbool(lookupUndoManager(handle).CanUndo())
+}
+
+//export goUndoManagerCanRedo
+func goUndoManagerCanRedo(handle C.gint) C.gboolean {
+	return gbool(lookupUndoManager(handle).CanRedo())
+}
+
+//export goUndoManagerUndo
+func goUndoManagerUndo(handle C.gint) {
+	lookupUndoManager(handle).Undo()
+}
+
+//export goUndoManagerRedo
+func goUndoManagerRedo(handle C.gint) {
+	lookupUndoManager(handle).Redo()
+}
+
+//export goUndoManagerBeginNotUndoableAction
+func goUndoManagerBeginNotUndoableAction(handle C.gint) {
+	lookupUndoManager(handle).BeginNotUndoableAction()
+}
+
+//export goUndoManagerEndNotUndoableAction
+func goUndoManagerEndNotUndoableAction(handle C.gint) {
+	lookupUndoManager(handle).EndNotUndoableAction()
+}
+
+//export goUndoManagerFinalize
+func goUndoManagerFinalize(handle C.gint) {
+	undoManagerRegistry.Lock()
+	delete(undoManagerRegistry.m, int(handle))
+	undoManagerRegistry.Unlock()
+}
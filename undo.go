@@ -0,0 +1,222 @@
+package sourceview
+
+// #cgo pkg-config: gtksourceview-3.0
+// #include <gtksourceview/gtksourcebuffer.h>
+// #include <gtksourceview/gtksourceundomanager.h>
+// #include "sourceview.go.h"
+// #include "undo.go.h"
+import "C"
+import (
+	"errors"
+	"sync"
+	"unsafe"
+
+	"github.com/gotk3/gotk3/glib"
+	"github.com/gotk3/gotk3/gtk"
+)
+
+func init() {
+	tm := []glib.TypeMarshaler{
+		{glib.Type(C.gtk_source_undo_manager_get_type()), marshalSourceUndoManager},
+	}
+	glib.RegisterGValueMarshalers(tm)
+
+	gtk.WrapMap["GtkSourceUndoManager"] = wrapSourceUndoManager
+}
+
+/*
+ * GtkSourceUndoManager
+ */
+
+// ISourceUndoManager is an interface type implemented by all structs
+// embedding a GtkSourceUndoManager. It is meant to be used as an argument
+// type for wrapper functions that wrap around a C function taking a
+// GtkSourceUndoManager.
+type ISourceUndoManager interface {
+	toSourceUndoManager() *C.GtkSourceUndoManager
+}
+
+// SourceUndoManager is a representation of GtkSourceView's
+// GtkSourceUndoManager GInterface.
+type SourceUndoManager struct {
+	*glib.Object
+}
+
+// native returns a pointer to the underlying GObject as a GtkSourceUndoManager.
+func (v *SourceUndoManager) native() *C.GtkSourceUndoManager {
+	if v == nil || v.GObject == nil {
+		return nil
+	}
+	p := unsafe.Pointer(v.GObject)
+	return C.toGtkSourceUndoManager(p)
+}
+
+func (v *SourceUndoManager) toSourceUndoManager() *C.GtkSourceUndoManager {
+	if v == nil {
+		return nil
+	}
+	return v.native()
+}
+
+func marshalSourceUndoManager(p uintptr) (interface{}, error) {
+	c := C.g_value_get_object((*C.GValue)(unsafe.Pointer(p)))
+	obj := glib.Take(unsafe.Pointer(c))
+	return wrapSourceUndoManager(obj), nil
+}
+
+func wrapSourceUndoManager(obj *glib.Object) *SourceUndoManager {
+	return &SourceUndoManager{obj}
+}
+
+// CanUndo is a wrapper around gtk_source_undo_manager_can_undo().
+func (v *SourceUndoManager) CanUndo() bool {
+	return gobool(C.gtk_source_undo_manager_can_undo(v.native()))
+}
+
+// CanRedo is a wrapper around gtk_source_undo_manager_can_redo().
+func (v *SourceUndoManager) CanRedo() bool {
+	return gobool(C.gtk_source_undo_manager_can_redo(v.native()))
+}
+
+// Undo is a wrapper around gtk_source_undo_manager_undo().
+func (v *SourceUndoManager) Undo() {
+	C.gtk_source_undo_manager_undo(v.native())
+}
+
+// Redo is a wrapper around gtk_source_undo_manager_redo().
+func (v *SourceUndoManager) Redo() {
+	C.gtk_source_undo_manager_redo(v.native())
+}
+
+// BeginNotUndoableAction is a wrapper around gtk_source_undo_manager_begin_not_undoable_action().
+func (v *SourceUndoManager) BeginNotUndoableAction() {
+	C.gtk_source_undo_manager_begin_not_undoable_action(v.native())
+}
+
+// EndNotUndoableAction is a wrapper around gtk_source_undo_manager_end_not_undoable_action().
+func (v *SourceUndoManager) EndNotUndoableAction() {
+	C.gtk_source_undo_manager_end_not_undoable_action(v.native())
+}
+
+// CanUndoChanged is a wrapper around gtk_source_undo_manager_can_undo_changed().
+// It emits the "can-undo-changed" signal and must be called by an UndoManager
+// whenever the value returned by its CanUndo method changes.
+func (v *SourceUndoManager) CanUndoChanged() {
+	C.gtk_source_undo_manager_can_undo_changed(v.native())
+}
+
+// CanRedoChanged is a wrapper around gtk_source_undo_manager_can_redo_changed().
+// It emits the "can-redo-changed" signal and must be called by an UndoManager
+// whenever the value returned by its CanRedo method changes.
+func (v *SourceUndoManager) CanRedoChanged() {
+	C.gtk_source_undo_manager_can_redo_changed(v.native())
+}
+
+// ConnectCanUndoChanged connects f to the "can-undo-changed" signal.
+func (v *SourceUndoManager) ConnectCanUndoChanged(f func()) (glib.SignalHandle, error) {
+	return v.Connect("can-undo-changed", f)
+}
+
+// ConnectCanRedoChanged connects f to the "can-redo-changed" signal.
+func (v *SourceUndoManager) ConnectCanRedoChanged(f func()) (glib.SignalHandle, error) {
+	return v.Connect("can-redo-changed", f)
+}
+
+// UndoManager is the interface implemented by Go undo managers. Its methods
+// are the virtual functions of GtkSourceUndoManagerIface; use
+// SourceUndoManagerNew to turn an implementation into a GtkSourceUndoManager
+// that can be set on a SourceBuffer.
+//
+// An UndoManager must call CanUndoChanged and CanRedoChanged on the
+// SourceUndoManager wrapping it whenever CanUndo or CanRedo change. It
+// receives that wrapper by also implementing UndoManagerInitializer.
+type UndoManager interface {
+	CanUndo() bool
+	CanRedo() bool
+	Undo()
+	Redo()
+	BeginNotUndoableAction()
+	EndNotUndoableAction()
+}
+
+// UndoManagerInitializer is implemented by an UndoManager that needs the
+// SourceUndoManager wrapping it, typically to emit "can-undo-changed" and
+// "can-redo-changed". SourceUndoManagerNew calls Init before returning, so
+// before the manager can be set on a SourceBuffer. The wrapper passed to
+// Init does not keep the object alive and may be kept by the UndoManager,
+// whose lifetime is that of the object.
+type UndoManagerInitializer interface {
+	Init(manager *SourceUndoManager)
+}
+
+var errNilUndoManager = errors.New("undo manager is nil")
+
+var undoManagerRegistry = struct {
+	sync.RWMutex
+	next int
+	m    map[int]UndoManager
+}{
+	next: 1,
+	m:    make(map[int]UndoManager),
+}
+
+// SourceUndoManagerNew creates a GtkSourceUndoManager whose virtual functions
+// are implemented by manager. manager is released when the returned object
+// is finalized. If manager implements UndoManagerInitializer, its Init
+// method is called with the returned SourceUndoManager first.
+func SourceUndoManagerNew(manager UndoManager) (*SourceUndoManager, error) {
+	if manager == nil {
+		return nil, errNilUndoManager
+	}
+
+	undoManagerRegistry.Lock()
+	id := undoManagerRegistry.next
+	undoManagerRegistry.next++
+	undoManagerRegistry.m[id] = manager
+	undoManagerRegistry.Unlock()
+
+	c := C.go_source_undo_manager_new(C.gint(id))
+	if c == nil {
+		undoManagerRegistry.Lock()
+		delete(undoManagerRegistry.m, id)
+		undoManagerRegistry.Unlock()
+		return nil, errNilPtr
+	}
+	// glib.Take adds a reference of its own. Drop the one returned by
+	// g_object_new so the object, and with it the registry entry, is
+	// released once it is no longer used from Go or C.
+	obj := glib.Take(unsafe.Pointer(c))
+	C.g_object_unref(C.gpointer(unsafe.Pointer(c)))
+	if initFn, ok := manager.(UndoManagerInitializer); ok {
+		// manager is owned by the object, so it gets a wrapper holding no
+		// reference; a counted one would keep the object alive forever.
+		initFn.Init(wrapSourceUndoManager(&glib.Object{GObject: glib.ToGObject(unsafe.Pointer(c))}))
+	}
+	return wrapSourceUndoManager(obj), nil
+}
+
+// lookupUndoManager returns the Go undo manager registered under handle.
+func lookupUndoManager(handle C.gint) UndoManager {
+	undoManagerRegistry.RLock()
+	defer undoManagerRegistry.RUnlock()
+	return undoManagerRegistry.m[int(handle)]
+}
+
+// GetUndoManager is a wrapper around gtk_source_buffer_get_undo_manager().
+func (v *SourceBuffer) GetUndoManager() (*SourceUndoManager, error) {
+	c := C.gtk_source_buffer_get_undo_manager(v.native())
+	if c == nil {
+		return nil, errNilPtr
+	}
+	return wrapSourceUndoManager(glib.Take(unsafe.Pointer(c))), nil
+}
+
+// SetUndoManager is a wrapper around gtk_source_buffer_set_undo_manager().
+// A nil manager restores the default undo manager.
+func (v *SourceBuffer) SetUndoManager(manager ISourceUndoManager) {
+	var cmanager *C.GtkSourceUndoManager
+	if manager != nil {
+		cmanager = manager.toSourceUndoManager()
+	}
+	C.gtk_source_buffer_set_undo_manager(v.native(), cmanager)
+}
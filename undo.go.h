@@ -0,0 +1,111 @@
+static GtkSourceUndoManager *
+toGtkSourceUndoManager(void *p)
+{
+	return (GTK_SOURCE_UNDO_MANAGER(p));
+}
+
+/*
+ * GoSourceUndoManager is a GObject implementing GtkSourceUndoManager by
+ * forwarding every virtual function to the Go UndoManager registered under
+ * its handle.
+ */
+
+extern gboolean goUndoManagerCanUndo(gint handle);
+extern gboolean goUndoManagerCanRedo(gint handle);
+extern void goUndoManagerUndo(gint handle);
+extern void goUndoManagerRedo(gint handle);
+extern void goUndoManagerBeginNotUndoableAction(gint handle);
+extern void goUndoManagerEndNotUndoableAction(gint handle);
+extern void goUndoManagerFinalize(gint handle);
+
+typedef struct {
+	GObject parent_instance;
+	gint handle;
+} GoSourceUndoManager;
+
+typedef struct {
+	GObjectClass parent_class;
+} GoSourceUndoManagerClass;
+
+static void go_source_undo_manager_iface_init(GtkSourceUndoManagerIface *iface);
+
+G_DEFINE_TYPE_WITH_CODE(GoSourceUndoManager, go_source_undo_manager, G_TYPE_OBJECT,
+	G_IMPLEMENT_INTERFACE(GTK_SOURCE_TYPE_UNDO_MANAGER,
+		go_source_undo_manager_iface_init))
+
+#define GO_SOURCE_UNDO_MANAGER_HANDLE(p) (((GoSourceUndoManager *)(p))->handle)
+
+static gboolean
+go_source_undo_manager_can_undo(GtkSourceUndoManager *manager)
+{
+	return goUndoManagerCanUndo(GO_SOURCE_UNDO_MANAGER_HANDLE(manager));
+}
+
+static gboolean
+go_source_undo_manager_can_redo(GtkSourceUndoManager *manager)
+{
+	return goUndoManagerCanRedo(GO_SOURCE_UNDO_MANAGER_HANDLE(manager));
+}
+
+static void
+go_source_undo_manager_undo(GtkSourceUndoManager *manager)
+{
+	goUndoManagerUndo(GO_SOURCE_UNDO_MANAGER_HANDLE(manager));
+}
+
+static void
+go_source_undo_manager_redo(GtkSourceUndoManager *manager)
+{
+	goUndoManagerRedo(GO_SOURCE_UNDO_MANAGER_HANDLE(manager));
+}
+
+static void
+go_source_undo_manager_begin_not_undoable_action(GtkSourceUndoManager *manager)
+{
+	goUndoManagerBeginNotUndoableAction(GO_SOURCE_UNDO_MANAGER_HANDLE(manager));
+}
+
+static void
+go_source_undo_manager_end_not_undoable_action(GtkSourceUndoManager *manager)
+{
+	goUndoManagerEndNotUndoableAction(GO_SOURCE_UNDO_MANAGER_HANDLE(manager));
+}
+
+static void
+go_source_undo_manager_finalize(GObject *object)
+{
+	goUndoManagerFinalize(GO_SOURCE_UNDO_MANAGER_HANDLE(object));
+	G_OBJECT_CLASS(go_source_undo_manager_parent_class)->finalize(object);
+}
+
+static void
+go_source_undo_manager_class_init(GoSourceUndoManagerClass *klass)
+{
+	G_OBJECT_CLASS(klass)->finalize = go_source_undo_manager_finalize;
+}
+
+static void
+go_source_undo_manager_init(GoSourceUndoManager *self)
+{
+}
+
+static void
+go_source_undo_manager_iface_init(GtkSourceUndoManagerIface *iface)
+{
+	iface->can_undo = go_source_undo_manager_can_undo;
+	iface->can_redo = go_source_undo_manager_can_redo;
+	iface->undo = go_source_undo_manager_undo;
+	iface->redo = go_source_undo_manager_redo;
+	iface->begin_not_undoable_action = go_source_undo_manager_begin_not_undoable_action;
+	iface->end_not_undoable_action = go_source_undo_manager_end_not_undoable_action;
+}
+
+static GtkSourceUndoManager *
+go_source_undo_manager_new(gint handle)
+{
+	GoSourceUndoManager *manager;
+
+	manager = g_object_new(go_source_undo_manager_get_type(), NULL);
+	manager->handle = handle;
+	return GTK_SOURCE_UNDO_MANAGER(manager);
+}